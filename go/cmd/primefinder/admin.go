@@ -0,0 +1,165 @@
+// admin.go
+package main
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "net"
+    "net/http"
+    "net/http/pprof"
+    "sync"
+    "time"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+// AdminStatus is the progress of a run as served on /status
+type AdminStatus struct {
+    RunID          string  `json:"run_id"`
+    State          string  `json:"state"` // running or cancelling
+    StartRange     int     `json:"start_range"`
+    EndRange       int     `json:"end_range"`
+    Range          int     `json:"range"` // index of the range being searched, for -ranges
+    Ranges         int     `json:"ranges"`
+    ChunksDone     int     `json:"chunks_done"`
+    Chunks         int     `json:"chunks"`
+    Percent        float64 `json:"percent"`
+    PrimesFound    int     `json:"primes_found"`
+    ElapsedSeconds float64 `json:"elapsed_seconds"`
+    ETASeconds     float64 `json:"eta_seconds,omitempty"`
+}
+
+// adminServer is the HTTP endpoint of -admin-addr, which lets a one-shot run
+// be inspected and cancelled: /status serves AdminStatus, /jobs/{id}/logs
+// the run's event log, /debug/pprof/ the runtime profiles (also reachable
+// as /pprof), and a POST to /cancel cancels the search as SIGINT would
+type adminServer struct {
+    cancel context.CancelFunc
+    server *http.Server
+    log    *jobLog
+    
+    mu      sync.Mutex
+    status  AdminStatus
+    started time.Time // when the current range started
+    latest  primefinder.Progress
+    updated time.Time // when latest arrived
+}
+
+// newAdminServer returns an admin server for a run of ranges ranges whose
+// /cancel calls cancel
+func newAdminServer(runID string, ranges int, cancel context.CancelFunc) *adminServer {
+    return &adminServer{cancel: cancel, log: newJobLog(), status: AdminStatus{RunID: runID, State: "running", Ranges: ranges}}
+}
+
+// handler returns the routes of the admin endpoint
+func (a *adminServer) handler() http.Handler {
+    mux := http.NewServeMux()
+    mux.HandleFunc("/status", a.serveStatus)
+    mux.HandleFunc("/cancel", a.serveCancel)
+    mux.HandleFunc("GET /jobs/{id}/logs", a.serveLogs)
+    mux.HandleFunc("/debug/pprof/", pprof.Index)
+    mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+    mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+    mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+    mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+    mux.Handle("/pprof", http.RedirectHandler("/debug/pprof/", http.StatusMovedPermanently))
+    return mux
+}
+
+// start listens on addr and serves in the background, returning the address
+// actually bound (addr may use port 0)
+func (a *adminServer) start(addr string) (net.Addr, error) {
+    listener, err := net.Listen("tcp", addr)
+    if err != nil {
+        return nil, err
+    }
+    a.server = &http.Server{Handler: a.handler(), ReadHeaderTimeout: 10 * time.Second}
+    go a.server.Serve(listener)
+    return listener.Addr(), nil
+}
+
+// close ends the event log and stops serving, giving readers following
+// the log a moment to receive its end
+func (a *adminServer) close() {
+    a.log.add(logEvent{Event: "done"})
+    if a.server != nil {
+        ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+        defer cancel()
+        if a.server.Shutdown(ctx) != nil {
+            a.server.Close()
+        }
+    }
+}
+
+// startRange records that the i-th range, r, is being searched
+func (a *adminServer) startRange(i int, r [2]int) {
+    a.mu.Lock()
+    defer a.mu.Unlock()
+    a.status.Range, a.status.StartRange, a.status.EndRange = i, r[0], r[1]
+    a.started, a.latest, a.updated = time.Now(), primefinder.Progress{}, time.Time{}
+    a.log.add(logEvent{Event: "range", Range: &r, Message: fmt.Sprintf("range %d of %d", i+1, a.status.Ranges)})
+}
+
+// update records a progress report of the current range
+func (a *adminServer) update(p primefinder.Progress) {
+    a.mu.Lock()
+    defer a.mu.Unlock()
+    a.latest, a.updated = p, time.Now()
+    a.log.chunk(p.Last)
+}
+
+// snapshot returns the current status
+func (a *adminServer) snapshot() AdminStatus {
+    a.mu.Lock()
+    defer a.mu.Unlock()
+    s, p := a.status, a.latest
+    s.ChunksDone, s.Chunks, s.PrimesFound = p.ChunksDone, p.Chunks, p.Primes
+    s.Percent = 100 * float64(p.Searched) / float64(max(p.Width, 1))
+    if a.updated.IsZero() {
+        if !a.started.IsZero() {
+            s.ElapsedSeconds = time.Since(a.started).Seconds()
+        }
+        return s
+    }
+    since := time.Since(a.updated)
+    s.ElapsedSeconds = (p.Elapsed + since).Seconds()
+    if eta, ok := progressETA(p, since); ok {
+        s.ETASeconds = eta.Seconds()
+    }
+    return s
+}
+
+func (a *adminServer) serveStatus(w http.ResponseWriter, r *http.Request) {
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(a.snapshot())
+}
+
+// serveLogs serves the event log of the run named by the path; there is only
+// one
+func (a *adminServer) serveLogs(w http.ResponseWriter, r *http.Request) {
+    a.mu.Lock()
+    runID := a.status.RunID
+    a.mu.Unlock()
+    if id := r.PathValue("id"); id != runID {
+        http.Error(w, fmt.Sprintf("no job %q; this run is %s", id, runID), http.StatusNotFound)
+        return
+    }
+    a.log.serve(w, r)
+}
+
+func (a *adminServer) serveCancel(w http.ResponseWriter, r *http.Request) {
+    if r.Method != http.MethodPost {
+        w.Header().Set("Allow", http.MethodPost)
+        http.Error(w, "use POST to cancel the run", http.StatusMethodNotAllowed)
+        return
+    }
+    a.mu.Lock()
+    a.status.State = "cancelling"
+    a.mu.Unlock()
+    a.log.add(logEvent{Event: "cancel", Message: "cancel requested on /cancel"})
+    a.cancel()
+    w.Header().Set("Content-Type", "application/json")
+    w.WriteHeader(http.StatusAccepted)
+    json.NewEncoder(w).Encode(a.snapshot())
+}
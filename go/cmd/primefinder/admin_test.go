@@ -0,0 +1,133 @@
+// admin_test.go
+package main
+
+import (
+    "context"
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "slices"
+    "testing"
+    "time"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+func TestAdminStatus(t *testing.T) {
+    a := newAdminServer("run1", 2, func() {})
+    a.startRange(1, [2]int{100, 200})
+    a.update(primefinder.Progress{ChunksDone: 1, Chunks: 4, Searched: 25, Width: 100, Primes: 7, Elapsed: time.Second})
+    
+    rec := httptest.NewRecorder()
+    a.handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/status", nil))
+    var status AdminStatus
+    if err := json.Unmarshal(rec.Body.Bytes(), &status); err != nil {
+        t.Fatalf("Bad /status body %q: %v", rec.Body.String(), err)
+    }
+    if status.RunID != "run1" || status.State != "running" || status.Range != 1 || status.StartRange != 100 ||
+        status.ChunksDone != 1 || status.Percent != 25 || status.PrimesFound != 7 || status.ETASeconds <= 0 {
+        t.Errorf("Unexpected status %+v", status)
+    }
+}
+
+func TestAdminCancel(t *testing.T) {
+    ctx, cancel := context.WithCancel(context.Background())
+    defer cancel()
+    a := newAdminServer("run1", 1, cancel)
+    
+    rec := httptest.NewRecorder()
+    a.handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/cancel", nil))
+    if rec.Code != http.StatusMethodNotAllowed || ctx.Err() != nil {
+        t.Errorf("GET /cancel = %d with ctx error %v, expected 405 and no cancellation", rec.Code, ctx.Err())
+    }
+    
+    rec = httptest.NewRecorder()
+    a.handler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/cancel", nil))
+    if rec.Code != http.StatusAccepted || ctx.Err() == nil || a.snapshot().State != "cancelling" {
+        t.Errorf("POST /cancel = %d with ctx error %v, expected 202 and cancellation", rec.Code, ctx.Err())
+    }
+}
+
+func TestAdminPprof(t *testing.T) {
+    a := newAdminServer("run1", 1, func() {})
+    addr, err := a.start("127.0.0.1:0")
+    if err != nil {
+        t.Fatal(err)
+    }
+    defer a.close()
+    
+    // /pprof redirects to the index, which lists the profiles
+    resp, err := http.Get("http://" + addr.String() + "/pprof")
+    if err != nil {
+        t.Fatal(err)
+    }
+    resp.Body.Close()
+    if resp.StatusCode != http.StatusOK || resp.Request.URL.Path != "/debug/pprof/" {
+        t.Errorf("GET /pprof ended at %s with %d", resp.Request.URL.Path, resp.StatusCode)
+    }
+}
+
+func TestAdminLogs(t *testing.T) {
+    a := newAdminServer("run1", 1, func() {})
+    a.startRange(0, [2]int{1, 1000})
+    a.update(primefinder.Progress{Last: primefinder.ChunkReport{Start: 1, End: 500, Primes: 95, Attempts: 1, Status: primefinder.ChunkDone}})
+    a.update(primefinder.Progress{Last: primefinder.ChunkReport{Start: 501, End: 800, Attempts: 2, Status: primefinder.ChunkDone}})
+    a.update(primefinder.Progress{Last: primefinder.ChunkReport{Start: 801, End: 1000, Attempts: 3, Status: primefinder.ChunkQuarantined}})
+    
+    rec := httptest.NewRecorder()
+    a.handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/jobs/other/logs", nil))
+    if rec.Code != http.StatusNotFound {
+        t.Errorf("GET /jobs/other/logs = %d, expected 404", rec.Code)
+    }
+    
+    rec = httptest.NewRecorder()
+    a.handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/jobs/run1/logs", nil))
+    var kinds []string
+    decoder := json.NewDecoder(rec.Body)
+    for decoder.More() {
+        var e logEvent
+        if err := decoder.Decode(&e); err != nil {
+            t.Fatal(err)
+        }
+        kinds = append(kinds, e.Event)
+    }
+    if expected := []string{"range", "chunk", "chunk", "retry", "chunk", "warning"}; !slices.Equal(kinds, expected) {
+        t.Errorf("Events %v, expected %v", kinds, expected)
+    }
+    
+    // A follower gets events as they arrive and returns once the run ends
+    addr, err := a.start("127.0.0.1:0")
+    if err != nil {
+        t.Fatal(err)
+    }
+    resp, err := http.Get("http://" + addr.String() + "/jobs/run1/logs?follow=1")
+    if err != nil {
+        t.Fatal(err)
+    }
+    defer resp.Body.Close()
+    go func() {
+        a.update(primefinder.Progress{Last: primefinder.ChunkReport{Start: 1001, End: 1100, Attempts: 1, Status: primefinder.ChunkDone}})
+        a.close()
+    }()
+    var last logEvent
+    followed := 0
+    for decoder = json.NewDecoder(resp.Body); decoder.More(); followed++ {
+        if err := decoder.Decode(&last); err != nil {
+            t.Fatal(err)
+        }
+    }
+    if followed != len(kinds)+2 || last.Event != "done" {
+        t.Errorf("Followed %d events ending with %q, expected %d ending with done", followed, last.Event, len(kinds)+2)
+    }
+}
+
+func TestJobLogDrops(t *testing.T) {
+    l := newJobLog()
+    for i := 0; i < maxLogEvents+10; i++ {
+        l.add(logEvent{Event: "chunk"})
+    }
+    events, next, _, done := l.since(0)
+    if next != maxLogEvents+10 || len(events) >= maxLogEvents || done {
+        t.Errorf("since(0) = %d events, next %d, done %v", len(events), next, done)
+    }
+}
@@ -0,0 +1,139 @@
+// analyze.go
+package main
+
+import (
+    "encoding/csv"
+    "flag"
+    "fmt"
+    "io"
+    "os"
+    "runtime"
+    "strconv"
+    "sync"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+// biasSample is one checkpoint of the running prime race between two
+// residue classes
+type biasSample struct {
+    x      int
+    countA int
+    countB int
+}
+
+// countResidues counts primes in [start, end] congruent to a and to b mod q
+func countResidues(start, end, q, a, b int) (countA, countB int) {
+    for n := start; n <= end; n++ {
+        if primefinder.IsPrime(n) {
+            switch n % q {
+            case a:
+                countA++
+            case b:
+                countB++
+            }
+        }
+    }
+    return countA, countB
+}
+
+// primeRace computes pi(x;q,a) and pi(x;q,b) at every step-th x in
+// [start, end]. Sample intervals are counted concurrently and prefix-summed,
+// so only one pair of counters per sample is kept rather than the primes.
+func primeRace(start, end, step, q, a, b, workers int) []biasSample {
+    if end < start {
+        return nil
+    }
+    intervals := (end-start)/step + 1
+    samples := make([]biasSample, intervals)
+    
+    jobs := make(chan int, workers)
+    var wg sync.WaitGroup
+    for w := 0; w < workers; w++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for i := range jobs {
+                lo := start + i*step
+                hi := lo + step - 1
+                if hi > end {
+                    hi = end
+                }
+                countA, countB := countResidues(lo, hi, q, a, b)
+                samples[i] = biasSample{x: hi, countA: countA, countB: countB}
+            }
+        }()
+    }
+    for i := 0; i < intervals; i++ {
+        jobs <- i
+    }
+    close(jobs)
+    wg.Wait()
+    
+    for i := 1; i < intervals; i++ {
+        samples[i].countA += samples[i-1].countA
+        samples[i].countB += samples[i-1].countB
+    }
+    return samples
+}
+
+// writeBiasCSV writes the race as a CSV time series
+func writeBiasCSV(w io.Writer, samples []biasSample, q, a, b int) error {
+    out := csv.NewWriter(w)
+    out.Write([]string{
+        "x",
+        fmt.Sprintf("pi_%d_%d", q, a),
+        fmt.Sprintf("pi_%d_%d", q, b),
+        "difference",
+    })
+    for _, s := range samples {
+        out.Write([]string{
+            strconv.Itoa(s.x),
+            strconv.Itoa(s.countA),
+            strconv.Itoa(s.countB),
+            strconv.Itoa(s.countA - s.countB),
+        })
+    }
+    out.Flush()
+    return out.Error()
+}
+
+// runAnalyze implements the `analyze` family of subcommands
+func runAnalyze(args []string) error {
+    if len(args) == 0 || args[0] != "bias" {
+        return fmt.Errorf("%w: usage: analyze bias [flags]", primefinder.ErrInvalidArgument)
+    }
+    
+    fs := flag.NewFlagSet("analyze bias", flag.ExitOnError)
+    start := fs.Int("start", 1, "Start of range")
+    end := fs.Int("end", 1000000, "End of range")
+    step := fs.Int("step", 1000, "Distance between sampled checkpoints")
+    modulus := fs.Int("modulus", 4, "Modulus q")
+    a := fs.Int("a", 3, "First residue class (reported as pi(x;q,a))")
+    b := fs.Int("b", 1, "Second residue class (subtracted from the first)")
+    workers := fs.Int("workers", runtime.NumCPU(), "Number of workers")
+    output := fs.String("output", "", "Output CSV file (default stdout)")
+    if err := parseFlags(fs, args[1:]); err != nil {
+        return err
+    }
+    
+    if *step < 1 || *modulus < 2 || *workers < 1 {
+        return fmt.Errorf("%w: step and workers must be positive and modulus at least 2", primefinder.ErrInvalidArgument)
+    }
+    if *a < 0 || *a >= *modulus || *b < 0 || *b >= *modulus || *a == *b {
+        return fmt.Errorf("%w: residues must be distinct and in [0, %d)", primefinder.ErrInvalidArgument, *modulus)
+    }
+    
+    samples := primeRace(*start, *end, *step, *modulus, *a, *b, *workers)
+    
+    out := os.Stdout
+    if *output != "" {
+        file, err := os.Create(*output)
+        if err != nil {
+            return fmt.Errorf("%w: %v", primefinder.ErrSinkWrite, err)
+        }
+        defer file.Close()
+        out = file
+    }
+    return writeBiasCSV(out, samples, *modulus, *a, *b)
+}
@@ -0,0 +1,19 @@
+// analyze_test.go
+package main
+
+import "testing"
+
+func TestPrimeRace(t *testing.T) {
+    // Primes up to 30: 3 mod 4 -> 3,7,11,19,23; 1 mod 4 -> 5,13,17,29
+    samples := primeRace(1, 30, 10, 4, 3, 1, 3)
+    expected := []biasSample{{10, 2, 1}, {20, 4, 3}, {30, 5, 4}}
+    
+    if len(samples) != len(expected) {
+        t.Fatalf("Expected %d samples, got %d", len(expected), len(samples))
+    }
+    for i, s := range samples {
+        if s != expected[i] {
+            t.Errorf("Sample %d = %+v, expected %+v", i, s, expected[i])
+        }
+    }
+}
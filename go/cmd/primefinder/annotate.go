@@ -0,0 +1,169 @@
+// annotate.go
+package main
+
+import (
+    "encoding/csv"
+    "flag"
+    "fmt"
+    "io"
+    "math/big"
+    "os"
+    "runtime"
+    "strconv"
+    "strings"
+    "sync"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+// primalityVerdict tests an integer field, returning whether it is prime and
+// the verdict behind the answer; ok is false when the field is not an
+// integer. Values too large for int are tested with big.Int.
+func primalityVerdict(field string) (prime bool, verdict primefinder.Verdict, ok bool) {
+    field = strings.TrimSpace(field)
+    n, ok := new(big.Int).SetString(field, 10)
+    if !ok {
+        return false, verdict, false
+    }
+    if n.IsInt64() {
+        prime = primefinder.IsProbablePrime(int(n.Int64()))
+    } else {
+        prime = n.Sign() > 0 && n.ProbablyPrime(20)
+    }
+    return prime, primefinder.ProbablePrimeVerdict(n), true
+}
+
+// annotationColumns returns the columns added for one field: is_prime and,
+// with verdicts, the method and the probability that a prime verdict is
+// wrong. Composites are proven, so their error probability is 0.
+func annotationColumns(field string, verdicts bool) []string {
+    prime, verdict, ok := primalityVerdict(field)
+    switch {
+    case !ok && verdicts:
+        return []string{"", "", ""}
+    case !ok:
+        return []string{""}
+    case !verdicts:
+        return []string{strconv.FormatBool(prime)}
+    }
+    errorProbability := 0.0
+    if prime {
+        errorProbability = verdict.ErrorProbability
+    }
+    return []string{strconv.FormatBool(prime), verdict.Method, strconv.FormatFloat(errorProbability, 'g', -1, 64)}
+}
+
+// annotateBatch appends a verdict for the given column to every record,
+// splitting the batch across workers
+func annotateBatch(records [][]string, column, workers int, verdicts bool) {
+    var wg sync.WaitGroup
+    per := (len(records) + workers - 1) / workers
+    for lo := 0; lo < len(records); lo += per {
+        hi := lo + per
+        if hi > len(records) {
+            hi = len(records)
+        }
+        wg.Add(1)
+        go func(part [][]string) {
+            defer wg.Done()
+            for i, record := range part {
+                field := ""
+                if column < len(record) {
+                    field = record[column]
+                }
+                part[i] = append(record, annotationColumns(field, verdicts)...)
+            }
+        }(records[lo:hi])
+    }
+    wg.Wait()
+}
+
+// annotateCSV streams CSV from r to w, adding an is_prime column computed
+// from the given zero-based column, and with verdicts method and
+// error_probability columns saying how it was decided. Records are processed in batches so
+// memory stays flat for arbitrarily large inputs.
+func annotateCSV(r io.Reader, w io.Writer, column, workers, batchSize int, header, verdicts bool) error {
+    in := csv.NewReader(r)
+    in.FieldsPerRecord = -1
+    out := csv.NewWriter(w)
+    
+    if header {
+        record, err := in.Read()
+        if err == io.EOF {
+            return nil
+        }
+        if err != nil {
+            return err
+        }
+        added := []string{"is_prime"}
+        if verdicts {
+            added = append(added, "method", "error_probability")
+        }
+        if err := out.Write(append(record, added...)); err != nil {
+            return err
+        }
+    }
+    
+    batch := make([][]string, 0, batchSize)
+    for done := false; !done; {
+        batch = batch[:0]
+        for len(batch) < batchSize {
+            record, err := in.Read()
+            if err == io.EOF {
+                done = true
+                break
+            }
+            if err != nil {
+                return err
+            }
+            batch = append(batch, record)
+        }
+        
+        annotateBatch(batch, column, workers, verdicts)
+        if err := out.WriteAll(batch); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+// runAnnotate implements `annotate -in data.csv -column 3`
+func runAnnotate(args []string) error {
+    fs := flag.NewFlagSet("annotate", flag.ExitOnError)
+    input := fs.String("in", "", "Input CSV file (default stdin)")
+    output := fs.String("out", "", "Output CSV file (default stdout)")
+    column := fs.Int("column", 1, "1-based column holding the numbers to test")
+    header := fs.Bool("header", true, "First row is a header")
+    workers := fs.Int("workers", runtime.NumCPU(), "Number of workers")
+    batchSize := fs.Int("batch", 4096, "Rows tested per batch")
+    verdicts := fs.Bool("verdicts", false, "Add method and error_probability columns saying how each number was tested")
+    if err := parseFlags(fs, args); err != nil {
+        return err
+    }
+    
+    if *column < 1 || *workers < 1 || *batchSize < 1 {
+        return fmt.Errorf("%w: column, workers, and batch must be positive", primefinder.ErrInvalidArgument)
+    }
+    
+    in := os.Stdin
+    if *input != "" {
+        file, err := os.Open(*input)
+        if err != nil {
+            return err
+        }
+        defer file.Close()
+        in = file
+    }
+    
+    out := os.Stdout
+    if *output != "" {
+        file, err := os.Create(*output)
+        if err != nil {
+            return fmt.Errorf("%w: %v", primefinder.ErrSinkWrite, err)
+        }
+        defer file.Close()
+        out = file
+    }
+    
+    return annotateCSV(in, out, *column-1, *workers, *batchSize, *header, *verdicts)
+}
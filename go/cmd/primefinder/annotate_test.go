@@ -0,0 +1,37 @@
+// annotate_test.go
+package main
+
+import (
+    "bytes"
+    "strings"
+    "testing"
+)
+
+func TestAnnotateCSV(t *testing.T) {
+    input := "id,value\na,7\nb,8\nc,not-a-number\nd,170141183460469231731687303715884105727\ne\n"
+    expected := "id,value,is_prime\na,7,true\nb,8,false\nc,not-a-number,\nd,170141183460469231731687303715884105727,true\ne,\n"
+    
+    var out bytes.Buffer
+    // A batch size of 2 exercises several batches
+    if err := annotateCSV(strings.NewReader(input), &out, 1, 3, 2, true, false); err != nil {
+        t.Fatalf("annotateCSV failed: %v", err)
+    }
+    if out.String() != expected {
+        t.Errorf("annotateCSV produced\n%s\nexpected\n%s", out.String(), expected)
+    }
+}
+
+func TestAnnotateCSVVerdicts(t *testing.T) {
+    input := "7\n8\nx\n170141183460469231731687303715884105727\n170141183460469231731687303715884105729\n"
+    expected := "7,true,baillie-psw,0\n8,false,baillie-psw,0\nx,,,\n" +
+        "170141183460469231731687303715884105727,true,baillie-psw,9.094947017729282e-13\n" +
+        "170141183460469231731687303715884105729,false,baillie-psw,0\n"
+    
+    var out bytes.Buffer
+    if err := annotateCSV(strings.NewReader(input), &out, 0, 2, 2, false, true); err != nil {
+        t.Fatalf("annotateCSV failed: %v", err)
+    }
+    if out.String() != expected {
+        t.Errorf("annotateCSV produced\n%s\nexpected\n%s", out.String(), expected)
+    }
+}
@@ -0,0 +1,226 @@
+// bench.go
+package main
+
+import (
+    "flag"
+    "fmt"
+    "math"
+    "os"
+    "runtime"
+    "text/tabwriter"
+    "time"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+// microBenchmark is one operation timed by `bench micro`
+type microBenchmark struct {
+    name      string
+    magnitude int
+    op        func()
+}
+
+// benchStats summarizes repeated timing samples in nanoseconds per operation
+type benchStats struct {
+    samples  int
+    mean     float64
+    variance float64 // sample variance, in ns^2
+    stddev   float64
+    ciLow    float64 // 95% confidence interval for the mean
+    ciHigh   float64
+}
+
+// tQuantile975 holds the two-sided 95% Student's t critical values for 1..30
+// degrees of freedom
+var tQuantile975 = []float64{
+    12.706, 4.303, 3.182, 2.776, 2.571, 2.447, 2.365, 2.306, 2.262, 2.228,
+    2.201, 2.179, 2.160, 2.145, 2.131, 2.120, 2.110, 2.101, 2.093, 2.086,
+    2.080, 2.074, 2.069, 2.064, 2.060, 2.056, 2.052, 2.048, 2.045, 2.042,
+}
+
+// summarize computes the mean, sample standard deviation, and a 95%
+// confidence interval for the mean
+func summarize(samples []float64) benchStats {
+    n := len(samples)
+    stats := benchStats{samples: n}
+    if n == 0 {
+        return stats
+    }
+    for _, s := range samples {
+        stats.mean += s
+    }
+    stats.mean /= float64(n)
+    stats.ciLow, stats.ciHigh = stats.mean, stats.mean
+    if n < 2 {
+        return stats
+    }
+    
+    for _, s := range samples {
+        stats.variance += (s - stats.mean) * (s - stats.mean)
+    }
+    stats.variance /= float64(n - 1)
+    stats.stddev = math.Sqrt(stats.variance)
+    
+    t := 1.96
+    if n-1 <= len(tQuantile975) {
+        t = tQuantile975[n-2]
+    }
+    margin := t * stats.stddev / math.Sqrt(float64(n))
+    stats.ciLow, stats.ciHigh = stats.mean-margin, stats.mean+margin
+    return stats
+}
+
+// measure times op over warmup+samples samples, calibrating the iteration
+// count so each sample runs for at least minSample. The first warmup
+// samples, which pay for cold caches, page faults and starting goroutines,
+// are returned apart from the steady-state ones.
+func measure(op func(), warmup, samples int, minSample time.Duration) (warm, steady []float64) {
+    iters := 1
+    for {
+        startTime := time.Now()
+        for i := 0; i < iters; i++ {
+            op()
+        }
+        if time.Since(startTime) >= minSample || iters >= 1<<30 {
+            break
+        }
+        iters *= 2
+    }
+    
+    results := make([]float64, warmup+samples)
+    for s := range results {
+        startTime := time.Now()
+        for i := 0; i < iters; i++ {
+            op()
+        }
+        results[s] = float64(time.Since(startTime).Nanoseconds()) / float64(iters)
+    }
+    return results[:warmup], results[warmup:]
+}
+
+// candidatesNear returns count odd numbers starting just above magnitude
+func candidatesNear(magnitude, count int) []int {
+    candidates := make([]int, count)
+    for i := range candidates {
+        candidates[i] = magnitude + 1 + 2*i
+    }
+    return candidates
+}
+
+// benchSearchWidth is the width of the ranges searched by the concurrent
+// search and pool benchmarks
+const benchSearchWidth = 1000
+
+// microBenchmarks builds the suite for the given magnitudes. Each primality
+// operation tests a batch of candidates near the magnitude; sieve marking
+// runs only where a full sieve fits comfortably in memory. A concurrent
+// search of a small range near the magnitude starts its workers on every
+// operation, while pool submits the same range to the long-lived pool.
+func microBenchmarks(magnitudes []int, pool *primefinder.Pool) []microBenchmark {
+    var suite []microBenchmark
+    for _, m := range magnitudes {
+        candidates := candidatesNear(m, 16)
+        suite = append(suite,
+            microBenchmark{"primefinder.IsPrime/trial-division", m, func() {
+                for _, c := range candidates {
+                    primefinder.IsPrime(c)
+                }
+            }},
+            microBenchmark{"primefinder.IsPrimeWheel/wheel-30", m, func() {
+                for _, c := range candidates {
+                    primefinder.IsPrimeWheel(c, 30)
+                }
+            }},
+            microBenchmark{"primefinder.IsPrimeWheel/wheel-210", m, func() {
+                for _, c := range candidates {
+                    primefinder.IsPrimeWheel(c, 210)
+                }
+            }},
+            microBenchmark{"primefinder.IsPrime/baillie-psw", m, func() {
+                for _, c := range candidates {
+                    primefinder.IsProbablePrime(c)
+                }
+            }},
+        )
+        if m <= 10000000 {
+            limit := m
+            suite = append(suite, microBenchmark{"sieve/marking", m, func() {
+                simpleSieve(limit)
+            }})
+        }
+        r := primefinder.Range{Start: m + 1, End: m + benchSearchWidth}
+        suite = append(suite,
+            microBenchmark{"search/concurrent", m, func() {
+                primefinder.FindRangeConcurrent(r.Start, r.End, runtime.NumCPU())
+            }},
+            microBenchmark{"search/pool", m, func() {
+                pool.Submit(r)
+                <-pool.Results()
+            }},
+        )
+    }
+    return suite
+}
+
+// comparedToTrial reports whether a benchmark tests the same candidates as
+// trial division, so its time is shown relative to it
+func comparedToTrial(name string) bool {
+    return name != "sieve/marking" && name != "search/concurrent" && name != "search/pool"
+}
+
+// runBench implements the `bench` family of subcommands
+func runBench(args []string) error {
+    if len(args) == 0 || args[0] != "micro" {
+        return fmt.Errorf("%w: usage: bench micro [flags]", primefinder.ErrInvalidArgument)
+    }
+    
+    fs := flag.NewFlagSet("bench micro", flag.ExitOnError)
+    samples := fs.Int("samples", 10, "Timing samples per benchmark, after the warm-up")
+    warmup := fs.Int("warmup", 3, "Warm-up samples per benchmark, timed but left out of the statistics")
+    minSample := fs.Duration("min-sample", 20*time.Millisecond, "Minimum duration of one sample")
+    maxExp := fs.Int("max-exp", 12, "Largest magnitude exponent k (magnitudes 10^3, 10^6, ... up to 10^k)")
+    if err := parseFlags(fs, args[1:]); err != nil {
+        return err
+    }
+    
+    if *samples < 2 {
+        return fmt.Errorf("%w: need at least 2 samples for a confidence interval", primefinder.ErrInvalidArgument)
+    }
+    if *warmup < 0 {
+        return fmt.Errorf("%w: -warmup must not be negative, got %d", primefinder.ErrInvalidArgument, *warmup)
+    }
+    
+    var magnitudes []int
+    for k, m := 3, 1000; k <= *maxExp; k, m = k+3, m*1000 {
+        magnitudes = append(magnitudes, m)
+    }
+    
+    pool, err := primefinder.New(runtime.NumCPU())
+    if err != nil {
+        return err
+    }
+    defer pool.Close()
+    
+    w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', tabwriter.AlignRight)
+    fmt.Fprintln(w, "benchmark\tmagnitude\tns/op\t±95% CI\tstddev\tvariance\tsamples\twarm-up ns/op\tvs trial\t")
+    baseline := map[int]float64{}
+    for _, b := range microBenchmarks(magnitudes, pool) {
+        warm, steady := measure(b.op, *warmup, *samples, *minSample)
+        stats := summarize(steady)
+        if b.name == "primefinder.IsPrime/trial-division" {
+            baseline[b.magnitude] = stats.mean
+        }
+        warmMean := "-"
+        if len(warm) > 0 {
+            warmMean = fmt.Sprintf("%.1f", summarize(warm).mean)
+        }
+        relative := "-"
+        if base, ok := baseline[b.magnitude]; ok && comparedToTrial(b.name) {
+            relative = fmt.Sprintf("%.2fx", stats.mean/base)
+        }
+        fmt.Fprintf(w, "%s\t%.0e\t%.1f\t%.1f\t%.1f\t%.4g\t%d\t%s\t%s\t\n",
+            b.name, float64(b.magnitude), stats.mean,
+            stats.ciHigh-stats.mean, stats.stddev, stats.variance, stats.samples, warmMean, relative)
+    }
+    return w.Flush()
+}
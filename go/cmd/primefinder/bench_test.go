@@ -0,0 +1,40 @@
+// bench_test.go
+package main
+
+import (
+    "math"
+    "testing"
+    "time"
+)
+
+func TestSummarize(t *testing.T) {
+    stats := summarize([]float64{2, 4, 4, 4, 5, 5, 7, 9})
+    if stats.mean != 5 {
+        t.Errorf("mean = %v, expected 5", stats.mean)
+    }
+    if math.Abs(stats.stddev-2.138) > 0.001 || math.Abs(stats.variance-32.0/7) > 1e-9 {
+        t.Errorf("stddev = %v and variance = %v, expected 2.138 and 32/7", stats.stddev, stats.variance)
+    }
+    // t(7) = 2.365, so the margin is 2.365 * 2.138 / sqrt(8)
+    if margin := stats.ciHigh - stats.mean; math.Abs(margin-1.788) > 0.001 {
+        t.Errorf("CI margin = %v, expected 1.788", margin)
+    }
+    
+    if single := summarize([]float64{3}); single.stddev != 0 || single.ciLow != 3 {
+        t.Errorf("Single sample stats = %+v", single)
+    }
+}
+
+func TestMeasureWarmup(t *testing.T) {
+    calls := 0
+    warm, steady := measure(func() { calls++ }, 3, 5, time.Microsecond)
+    if len(warm) != 3 || len(steady) != 5 {
+        t.Fatalf("measure returned %d warm-up and %d steady samples, expected 3 and 5", len(warm), len(steady))
+    }
+    if calls < 8 {
+        t.Errorf("op ran %d times for 8 samples", calls)
+    }
+    if warm, steady := measure(func() {}, 0, 2, time.Microsecond); len(warm) != 0 || len(steady) != 2 {
+        t.Errorf("no warm-up: got %d and %d samples", len(warm), len(steady))
+    }
+}
@@ -0,0 +1,120 @@
+// bigrange.go
+package main
+
+import (
+    "fmt"
+    "math/big"
+    "os"
+    "strings"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+// BigResult is the output of an arbitrary precision search. Range bounds and
+// primes are written as decimal strings since they may not fit in an int64.
+type BigResult struct {
+    RunID         string              `json:"run_id"`
+    StartRange    string              `json:"start_range"`
+    EndRange      string              `json:"end_range"`
+    PrimesFound   int                 `json:"primes_found"`
+    ExecutionTime float64             `json:"execution_time_seconds"`
+    Workers       int                 `json:"workers"`
+    Algorithm     string              `json:"algorithm"`
+    Verdict       primefinder.Verdict `json:"verdict"` // for the largest number of the range
+    Primes        []string            `json:"primes,omitempty"`
+}
+
+// parseBigNumber parses an arbitrary precision integer written in decimal,
+// as a power such as 2^64, or as a sum or difference of such terms
+func parseBigNumber(s string) (*big.Int, error) {
+    total := new(big.Int)
+    expr := strings.ReplaceAll(strings.TrimSpace(s), "-", "+-")
+    for _, term := range strings.Split(expr, "+") {
+        term = strings.TrimSpace(term)
+        if term == "" {
+            continue
+        }
+        negative := strings.HasPrefix(term, "-")
+        term = strings.TrimPrefix(term, "-")
+    
+        v := new(big.Int)
+        if base, exp, ok := strings.Cut(term, "^"); ok {
+            b, ok1 := new(big.Int).SetString(base, 10)
+            e, ok2 := new(big.Int).SetString(exp, 10)
+            if !ok1 || !ok2 || e.Sign() < 0 {
+                return nil, fmt.Errorf("invalid number %q", s)
+            }
+            v.Exp(b, e, nil)
+        } else if _, ok := v.SetString(term, 10); !ok {
+            return nil, fmt.Errorf("invalid number %q", s)
+        }
+    
+        if negative {
+            v.Neg(v)
+        }
+        total.Add(total, v)
+    }
+    return total, nil
+}
+
+// bigSearch holds the flags of the default search used by runFindBig
+type bigSearch struct {
+    start, end string
+    workers    int
+    savePrimes bool
+    output     string
+    jsonCompat string
+}
+
+// runFindBig searches a range given with -big-start/-big-end using
+// arbitrary precision arithmetic
+func runFindBig(opts bigSearch) error {
+    start, err := parseBigNumber(opts.start)
+    if err != nil {
+        return fmt.Errorf("%w: -big-start: %v", primefinder.ErrInvalidArgument, err)
+    }
+    end, err := parseBigNumber(opts.end)
+    if err != nil {
+        return fmt.Errorf("%w: -big-end: %v", primefinder.ErrInvalidArgument, err)
+    }
+    if err := primefinder.ValidateBigRange(start, end, opts.workers); err != nil {
+        return err
+    }
+    
+    runID := newRunID()
+    output := expandRunID(opts.output, runID)
+    fmt.Println(tr("Run %s: finding probable primes from %s to %s", runID, start, end))
+    fmt.Println(tr("Running arbitrary precision version with %d workers...", opts.workers))
+    
+    primes, duration := primefinder.FindRangeBig(start, end, opts.workers)
+    fmt.Println(tr("Found %d probable primes in %v", len(primes), duration))
+    
+    result := BigResult{
+        RunID:         runID,
+        StartRange:    start.String(),
+        EndRange:      end.String(),
+        PrimesFound:   len(primes),
+        ExecutionTime: duration.Seconds(),
+        Workers:       opts.workers,
+        Algorithm:     "probable-prime",
+        Verdict:       primefinder.ProbablePrimeVerdict(end),
+    }
+    if opts.savePrimes {
+        result.Primes = make([]string, len(primes))
+        for i, p := range primes {
+            result.Primes[i] = p.String()
+        }
+    }
+    
+    file, err := os.Create(output)
+    if err != nil {
+        return fmt.Errorf("%w: %v", primefinder.ErrSinkWrite, err)
+    }
+    defer file.Close()
+    if err := writeJSON(file, result, opts.jsonCompat); err != nil {
+        return fmt.Errorf("%w: %s: %v", primefinder.ErrSinkWrite, output, err)
+    }
+    
+    fmt.Println(tr("Results saved to %s", output))
+    return nil
+}
@@ -0,0 +1,30 @@
+// bigrange_test.go
+package main
+
+import "testing"
+
+func TestParseBigNumber(t *testing.T) {
+    tests := []struct {
+        input    string
+        expected string
+        wantErr  bool
+    }{
+        {"12345", "12345", false},
+        {"2^64", "18446744073709551616", false},
+        {"2^64+13", "18446744073709551629", false},
+        {"2^127-1", "170141183460469231731687303715884105727", false},
+        {"10^30 + 10^3", "1000000000000000000000000001000", false},
+        {"2^x", "", true},
+        {"1e20", "", true},
+    }
+    for _, tt := range tests {
+        got, err := parseBigNumber(tt.input)
+        if (err != nil) != tt.wantErr {
+            t.Errorf("parseBigNumber(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+            continue
+        }
+        if err == nil && got.String() != tt.expected {
+            t.Errorf("parseBigNumber(%q) = %s, expected %s", tt.input, got, tt.expected)
+        }
+    }
+}
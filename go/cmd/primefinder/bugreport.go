@@ -0,0 +1,191 @@
+// bugreport.go
+package main
+
+import (
+    "flag"
+    "fmt"
+    "io"
+    "net/url"
+    "os"
+    "runtime"
+    "runtime/debug"
+    "strings"
+    "sync"
+    "time"
+)
+
+// recentMessageLimit is how many recent user-facing messages a diagnostic
+// bundle includes
+const recentMessageLimit = 50
+
+// recentMessages keeps the latest messages printed through tr, oldest first
+var recentMessages struct {
+    sync.Mutex
+    lines []string
+}
+
+// recordMessage adds a message to the recent message tail
+func recordMessage(msg string) {
+    recentMessages.Lock()
+    defer recentMessages.Unlock()
+    if len(recentMessages.lines) == recentMessageLimit {
+        recentMessages.lines = recentMessages.lines[1:]
+    }
+    recentMessages.lines = append(recentMessages.lines, msg)
+}
+
+// secretWords mark flags whose values are never written to a bundle
+var secretWords = []string{"token", "secret", "password", "passwd", "key", "auth", "credential"}
+
+// diagnosticEnv lists the environment variables that affect a run
+var diagnosticEnv = []string{"GOGC", "GOMEMLIMIT", "GOMAXPROCS", "GODEBUG", "LC_ALL", "LC_MESSAGES", "LANG"}
+
+// redactArgs returns args with the values of secret-looking flags and any
+// passwords embedded in URLs replaced
+func redactArgs(args []string) []string {
+    redacted := make([]string, len(args))
+    redactNext := false
+    for i, arg := range args {
+        switch {
+        case redactNext:
+            redacted[i] = "[REDACTED]"
+            redactNext = false
+        case strings.HasPrefix(arg, "-"):
+            name, _, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+            if isSecretName(name) {
+                if hasValue {
+                    redacted[i] = arg[:strings.Index(arg, "=")+1] + "[REDACTED]"
+                } else {
+                    redacted[i] = arg
+                    redactNext = true
+                }
+                continue
+            }
+            redacted[i] = redactURL(arg)
+        default:
+            redacted[i] = redactURL(arg)
+        }
+    }
+    return redacted
+}
+
+// isSecretName reports whether a flag name looks like it holds a secret
+func isSecretName(name string) bool {
+    name = strings.ToLower(name)
+    for _, word := range secretWords {
+        if strings.Contains(name, word) {
+            return true
+        }
+    }
+    return false
+}
+
+// redactURL hides the password of a URL in s, including one given as the
+// value of a -flag=URL argument
+func redactURL(s string) string {
+    prefix, value := "", s
+    if i := strings.Index(s, "="); i >= 0 && strings.HasPrefix(s, "-") {
+        prefix, value = s[:i+1], s[i+1:]
+    }
+    u, err := url.Parse(value)
+    if err != nil || u.User == nil {
+        return s
+    }
+    if _, ok := u.User.Password(); ok {
+        u.User = url.UserPassword(u.User.Username(), "REDACTED")
+    }
+    return prefix + u.String()
+}
+
+// writeBundle writes a diagnostic report: what happened, the redacted
+// command line and environment, runtime statistics, goroutine stacks, and
+// the most recent messages
+func writeBundle(w io.Writer, reason string, stack []byte) {
+    fmt.Fprintf(w, "prime-finder diagnostic bundle\n")
+    fmt.Fprintf(w, "time: %s\n", time.Now().UTC().Format(time.RFC3339))
+    fmt.Fprintf(w, "reason: %s\n", reason)
+    
+    fmt.Fprintf(w, "\n== command line\n%s\n", strings.Join(redactArgs(os.Args), " "))
+    
+    fmt.Fprintf(w, "\n== environment\n")
+    for _, name := range diagnosticEnv {
+        if v, ok := os.LookupEnv(name); ok {
+            fmt.Fprintf(w, "%s=%s\n", name, v)
+        }
+    }
+    
+    var mem runtime.MemStats
+    runtime.ReadMemStats(&mem)
+    fmt.Fprintf(w, "\n== runtime\n")
+    fmt.Fprintf(w, "go: %s %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+    fmt.Fprintf(w, "cpus: %d, GOMAXPROCS: %d, goroutines: %d\n", runtime.NumCPU(), runtime.GOMAXPROCS(0), runtime.NumGoroutine())
+    fmt.Fprintf(w, "heap: %d bytes in use, %d bytes from OS, %d GC cycles\n", mem.HeapInuse, mem.Sys, mem.NumGC)
+    if info, ok := debug.ReadBuildInfo(); ok {
+        fmt.Fprintf(w, "module: %s %s\n", info.Main.Path, info.Main.Version)
+        for _, s := range info.Settings {
+            if s.Key == "vcs.revision" || s.Key == "vcs.modified" {
+                fmt.Fprintf(w, "%s: %s\n", s.Key, s.Value)
+            }
+        }
+    }
+    
+    if stack != nil {
+        fmt.Fprintf(w, "\n== panicking goroutine\n%s\n", stack)
+    }
+    all := make([]byte, 1<<20)
+    all = all[:runtime.Stack(all, true)]
+    fmt.Fprintf(w, "\n== all goroutines\n%s\n", all)
+    
+    recentMessages.Lock()
+    fmt.Fprintf(w, "\n== recent messages\n%s\n", strings.Join(recentMessages.lines, "\n"))
+    recentMessages.Unlock()
+}
+
+// writeBundleFile writes a diagnostic bundle to path, or to a new temp file
+// if path is empty, and returns the file's name
+func writeBundleFile(path, reason string, stack []byte) (string, error) {
+    var file *os.File
+    var err error
+    if path == "" {
+        file, err = os.CreateTemp("", "prime-finder-bundle-*.txt")
+    } else {
+        file, err = os.Create(path)
+    }
+    if err != nil {
+        return "", err
+    }
+    writeBundle(file, reason, stack)
+    return file.Name(), file.Close()
+}
+
+// reportCrash writes a bundle for a failure and tells the user where it is
+func reportCrash(reason string, stack []byte) {
+    if path, err := writeBundleFile("", reason, stack); err == nil {
+        fmt.Fprintln(os.Stderr, tr("Diagnostic bundle written to %s; please attach it to a bug report", path))
+    }
+}
+
+// crashHandler writes a diagnostic bundle for a panic on the main goroutine
+// before letting the panic continue
+func crashHandler() {
+    if r := recover(); r != nil {
+        reportCrash(fmt.Sprintf("panic: %v", r), debug.Stack())
+        panic(r)
+    }
+}
+
+// runBugReport implements `bugreport`
+func runBugReport(args []string) error {
+    fs := flag.NewFlagSet("bugreport", flag.ExitOnError)
+    output := fs.String("output", "", "Write the bundle here (default: a new file in the temp directory)")
+    if err := parseFlags(fs, args); err != nil {
+        return err
+    }
+    
+    path, err := writeBundleFile(*output, "requested with bugreport", nil)
+    if err != nil {
+        return err
+    }
+    fmt.Println(tr("Diagnostic bundle written to %s", path))
+    return nil
+}
@@ -0,0 +1,129 @@
+// chains.go
+package main
+
+import (
+    "flag"
+    "fmt"
+    "math"
+    "os"
+    "runtime"
+    "sync"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+// CunninghamChain is a maximal chain p, 2p±1, 4p±3, ... of primes
+type CunninghamChain struct {
+    Kind   int   `json:"kind"`
+    Length int   `json:"length"`
+    Primes []int `json:"primes"`
+}
+
+// chainNext returns the next chain member after p for the given kind: 2p+1
+// for the first kind and 2p-1 for the second
+func chainNext(p, kind int) int {
+    if kind == 1 {
+        return 2*p + 1
+    }
+    return 2*p - 1
+}
+
+// isChainStart reports whether prime p begins a Cunningham chain of the
+// given kind, i.e. it does not extend a shorter chain. For the first kind
+// that means p is not a safe prime.
+func isChainStart(p, kind int) bool {
+    if kind == 1 {
+        return !isSafePrime(p)
+    }
+    return (p+1)%2 != 0 || !primefinder.IsProbablePrime((p+1)/2)
+}
+
+// extendChain follows a chain from its first prime until the next member is
+// composite (or would overflow)
+func extendChain(p, kind int) []int {
+    chain := []int{p}
+    for p <= (math.MaxInt-1)/2 {
+        p = chainNext(p, kind)
+        if !primefinder.IsProbablePrime(p) {
+            break
+        }
+        chain = append(chain, p)
+    }
+    return chain
+}
+
+// findCunninghamChains returns every complete chain of the given kind that
+// starts in [start, end] and has at least minLength members. Chains are
+// extended concurrently and returned in order of their first prime.
+func findCunninghamChains(start, end, kind, minLength, workers int) []CunninghamChain {
+    primes, _ := primefinder.FindRangeConcurrent(start, end, workers)
+    found := make([][]int, len(primes))
+    
+    jobs := make(chan int, workers)
+    var wg sync.WaitGroup
+    for w := 0; w < workers; w++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for i := range jobs {
+                if isChainStart(primes[i], kind) {
+                    if chain := extendChain(primes[i], kind); len(chain) >= minLength {
+                        found[i] = chain
+                    }
+                }
+            }
+        }()
+    }
+    for i := range primes {
+        jobs <- i
+    }
+    close(jobs)
+    wg.Wait()
+    
+    var chains []CunninghamChain
+    for _, chain := range found {
+        if chain != nil {
+            chains = append(chains, CunninghamChain{Kind: kind, Length: len(chain), Primes: chain})
+        }
+    }
+    return chains
+}
+
+// runChains implements `chains -kind 1st -min-length 4`
+func runChains(args []string) error {
+    fs := flag.NewFlagSet("chains", flag.ExitOnError)
+    start := fs.Int("start", 1, "Start of range for the first prime of each chain")
+    end := fs.Int("end", 100000, "End of range for the first prime of each chain")
+    kindName := fs.String("kind", "1st", "Chain kind: 1st (p -> 2p+1) or 2nd (p -> 2p-1)")
+    minLength := fs.Int("min-length", 4, "Minimum chain length to report")
+    workers := fs.Int("workers", runtime.NumCPU(), "Number of workers")
+    output := fs.String("output", "", "Output JSON file (default stdout)")
+    if err := parseFlags(fs, args); err != nil {
+        return err
+    }
+    
+    kinds := map[string]int{"1st": 1, "first": 1, "2nd": 2, "second": 2}
+    kind, ok := kinds[*kindName]
+    if !ok {
+        return fmt.Errorf("%w: unknown chain kind %q", primefinder.ErrInvalidArgument, *kindName)
+    }
+    if err := primefinder.ValidateRange(*start, *end, *workers); err != nil {
+        return err
+    }
+    
+    chains := findCunninghamChains(*start, *end, kind, *minLength, *workers)
+    
+    out := os.Stdout
+    if *output != "" {
+        file, err := os.Create(*output)
+        if err != nil {
+            return fmt.Errorf("%w: %v", primefinder.ErrSinkWrite, err)
+        }
+        defer file.Close()
+        out = file
+    }
+    if chains == nil {
+        chains = []CunninghamChain{}
+    }
+    return writeJSON(out, chains, "")
+}
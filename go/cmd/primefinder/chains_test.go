@@ -0,0 +1,27 @@
+// chains_test.go
+package main
+
+import (
+    "reflect"
+    "testing"
+)
+
+func TestFindCunninghamChains(t *testing.T) {
+    // First kind: 2, 5, 11, 23, 47 is the classic chain of length 5
+    chains := findCunninghamChains(1, 100, 1, 4, 3)
+    if len(chains) == 0 || !reflect.DeepEqual(chains[0].Primes, []int{2, 5, 11, 23, 47}) {
+        t.Errorf("Unexpected first-kind chains: %+v", chains)
+    }
+    for _, c := range chains {
+        // Chains must be complete: 5 belongs to the chain starting at 2
+        if c.Primes[0] == 5 {
+            t.Errorf("Chain starting at 5 is not maximal")
+        }
+    }
+    
+    // Second kind: 2, 3, 5 and 1531, 3061, 6121, 12241, 24481
+    chains = findCunninghamChains(1500, 1600, 2, 5, 2)
+    if len(chains) != 1 || !reflect.DeepEqual(chains[0].Primes, []int{1531, 3061, 6121, 12241, 24481}) {
+        t.Errorf("Unexpected second-kind chains: %+v", chains)
+    }
+}
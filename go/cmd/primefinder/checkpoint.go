@@ -0,0 +1,118 @@
+// checkpoint.go
+package main
+
+import (
+    "encoding/json"
+    "fmt"
+    "os"
+    "time"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+// checkpointChunk caps chunk sizes while checkpointing, so the searched
+// prefix advances in steps small enough to be worth saving
+const checkpointChunk = 1 << 22
+
+// Checkpoint is the saved state of a long search: everything up to Covered
+// has been searched and holds PrimesFound primes
+type Checkpoint struct {
+    StartRange     int     `json:"start_range"`
+    EndRange       int     `json:"end_range"`
+    Algorithm      string  `json:"algorithm"`
+    Stride         int     `json:"stride,omitempty"`
+    Offset         int     `json:"offset,omitempty"`
+    Covered        int     `json:"covered"`
+    PrimesFound    int     `json:"primes_found"`
+    ElapsedSeconds float64 `json:"elapsed_seconds"`
+    SavedAt        string  `json:"saved_at"`
+}
+
+// remaining returns the part of the range still to be searched
+func (c Checkpoint) remaining() (int, int) {
+    return c.Covered + 1, c.EndRange
+}
+
+// loadState reads saved state written by saveState into v
+func loadState(path string, v interface{}) error {
+    data, err := os.ReadFile(path)
+    if err != nil {
+        return fmt.Errorf("%w: -resume: %v", primefinder.ErrInvalidArgument, err)
+    }
+    if err := json.Unmarshal(data, v); err != nil {
+        return fmt.Errorf("%w: -resume: %s: %v", primefinder.ErrInvalidArgument, path, err)
+    }
+    return nil
+}
+
+// saveState writes v to path as JSON. The file is replaced by renaming so a
+// crash mid-write leaves the previous state intact.
+func saveState(path string, v interface{}) error {
+    data, err := json.MarshalIndent(v, "", "  ")
+    if err != nil {
+        return err
+    }
+    tmp := path + ".tmp"
+    if err := os.WriteFile(tmp, data, 0644); err != nil {
+        return fmt.Errorf("%w: %v", primefinder.ErrSinkWrite, err)
+    }
+    if err := os.Rename(tmp, path); err != nil {
+        return fmt.Errorf("%w: %v", primefinder.ErrSinkWrite, err)
+    }
+    return nil
+}
+
+// loadCheckpoint reads a checkpoint written by a previous run
+func loadCheckpoint(path string) (Checkpoint, error) {
+    var c Checkpoint
+    if err := loadState(path, &c); err != nil {
+        return c, err
+    }
+    if c.Covered < c.StartRange-1 || c.Covered > c.EndRange {
+        return c, fmt.Errorf("%w: -resume: %s: covered %d is outside [%d, %d]",
+            primefinder.ErrInvalidArgument, path, c.Covered, c.StartRange, c.EndRange)
+    }
+    return c, nil
+}
+
+// checkpointer saves search progress at most once per interval. base holds
+// the state the search resumed from, so saved counts include earlier runs.
+type checkpointer struct {
+    path     string
+    interval time.Duration
+    base     Checkpoint
+    latest   Checkpoint
+    last     time.Time
+    err      error // first failed save
+}
+
+// newCheckpointer starts checkpointing from base
+func newCheckpointer(path string, interval time.Duration, base Checkpoint) *checkpointer {
+    return &checkpointer{path: path, interval: interval, base: base, latest: base, last: time.Now()}
+}
+
+// update records a progress report, saving it if the interval has passed
+func (c *checkpointer) update(p primefinder.Progress) {
+    c.latest = c.base
+    c.latest.Covered = p.Covered
+    c.latest.PrimesFound += p.CoveredPrimes
+    c.latest.ElapsedSeconds += p.Elapsed.Seconds()
+    if time.Since(c.last) >= c.interval {
+        c.save()
+    }
+}
+
+// flush saves the latest state and returns the first save error, if any
+func (c *checkpointer) flush() error {
+    c.save()
+    return c.err
+}
+
+// save writes the latest state, keeping the first failure
+func (c *checkpointer) save() {
+    c.last = time.Now()
+    c.latest.SavedAt = c.last.UTC().Format(time.RFC3339)
+    if err := saveState(c.path, c.latest); err != nil && c.err == nil {
+        c.err = err
+    }
+}
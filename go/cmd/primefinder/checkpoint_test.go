@@ -0,0 +1,70 @@
+// checkpoint_test.go
+package main
+
+import (
+    "errors"
+    "os"
+    "path/filepath"
+    "testing"
+    "time"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+func TestCheckpointRoundTrip(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "run.ckpt")
+    saved := Checkpoint{StartRange: 1, EndRange: 1000, Algorithm: "trial", Covered: 500, PrimesFound: 95, ElapsedSeconds: 1.5}
+    if err := saveState(path, saved); err != nil {
+        t.Fatal(err)
+    }
+    loaded, err := loadCheckpoint(path)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if loaded != saved {
+        t.Errorf("loaded %+v, expected %+v", loaded, saved)
+    }
+    if lo, hi := loaded.remaining(); lo != 501 || hi != 1000 {
+        t.Errorf("remaining = [%d, %d], expected [501, 1000]", lo, hi)
+    }
+    
+    if _, err := loadCheckpoint(filepath.Join(t.TempDir(), "missing")); !errors.Is(err, primefinder.ErrInvalidArgument) {
+        t.Errorf("missing checkpoint: got %v, expected ErrInvalidArgument", err)
+    }
+    bad := Checkpoint{StartRange: 10, EndRange: 20, Covered: 30}
+    if err := saveState(path, bad); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := loadCheckpoint(path); !errors.Is(err, primefinder.ErrInvalidArgument) {
+        t.Errorf("covered past the end: got %v, expected ErrInvalidArgument", err)
+    }
+}
+
+func TestCheckpointer(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "run.ckpt")
+    base := Checkpoint{StartRange: 1, EndRange: 1000, Covered: 500, PrimesFound: 95, ElapsedSeconds: 1}
+    c := newCheckpointer(path, time.Hour, base)
+    
+    // Within the interval nothing is written
+    c.update(primefinder.Progress{Covered: 700, CoveredPrimes: 30, Elapsed: time.Second})
+    if _, err := os.Stat(path); !os.IsNotExist(err) {
+        t.Fatalf("checkpoint written before the interval: %v", err)
+    }
+    
+    // Counts accumulate on top of the resumed state
+    if err := c.flush(); err != nil {
+        t.Fatal(err)
+    }
+    got, err := loadCheckpoint(path)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if got.Covered != 700 || got.PrimesFound != 125 || got.ElapsedSeconds != 2 {
+        t.Errorf("saved %+v, expected covered 700 with 125 primes after 2s", got)
+    }
+    
+    c.path = filepath.Join(t.TempDir(), "missing", "run.ckpt")
+    if err := c.flush(); !errors.Is(err, primefinder.ErrSinkWrite) {
+        t.Errorf("unwritable checkpoint: got %v, expected ErrSinkWrite", err)
+    }
+}
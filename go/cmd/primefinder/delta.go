@@ -0,0 +1,267 @@
+// delta.go
+package main
+
+import (
+    "bytes"
+    "encoding/json"
+    "flag"
+    "fmt"
+    "os"
+    "sort"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+// Delta describes what a newer result adds to (or drops from) an older one
+type Delta struct {
+    OldRange        [2]int   `json:"old_range"`
+    NewRange        [2]int   `json:"new_range"`
+    AddedCoverage   [][2]int `json:"added_coverage"`
+    RemovedCoverage [][2]int `json:"removed_coverage"`
+    CountChange     int      `json:"count_change"`
+    PrimesAdded     []int    `json:"primes_added,omitempty"`
+    PrimesRemoved   []int    `json:"primes_removed,omitempty"`
+    PrimesCompared  bool     `json:"primes_compared"`
+}
+
+// loadResult reads a Result previously written by the CLI as JSON or with
+// -format=bin. CSV and NDJSON outputs list primes without the range that was
+// searched, and -json-compat renames every key, so they are refused, as are
+// binary files that do not record every prime of a complete search.
+func loadResult(path string) (*Result, error) {
+    data, err := os.ReadFile(path)
+    if err != nil {
+        return nil, err
+    }
+    if bytes.HasPrefix(data, binaryMagic[:3]) {
+        ranges, incomplete, err := readBinaryPrimes(bytes.NewReader(data))
+        if err != nil {
+            return nil, fmt.Errorf("%s: %v", path, err)
+        }
+        if incomplete != "" {
+            return nil, fmt.Errorf("%s: %s; compare the JSON result instead", path, incomplete)
+        }
+        return binaryResult(ranges), nil
+    }
+    
+    var keys map[string]json.RawMessage
+    if err := json.NewDecoder(bytes.NewReader(data)).Decode(&keys); err != nil {
+        return nil, fmt.Errorf("%s: not a JSON or -format=bin result (-format=csv output cannot be read back): %v", path, err)
+    }
+    switch {
+    case keys["prime"] != nil:
+        return nil, fmt.Errorf("%s: -format=ndjson output lists primes without the range searched; use a JSON or -format=bin result", path)
+    case keys["runId"] != nil:
+        return nil, fmt.Errorf("%s: -json-compat output cannot be read back; rerun without -json-compat", path)
+    }
+    var result Result
+    if err := json.Unmarshal(data, &result); err != nil {
+        return nil, fmt.Errorf("%s: %v", path, err)
+    }
+    return &result, nil
+}
+
+// binaryResult rebuilds the ranges, count and primes of a result from the
+// ranges of a -format=bin file
+func binaryResult(ranges []RangeResult) *Result {
+    result := &Result{Primes: []int{}}
+    for i, r := range ranges {
+        if i == 0 {
+            result.StartRange, result.EndRange = r.StartRange, r.EndRange
+        }
+        result.StartRange = min(result.StartRange, r.StartRange)
+        result.EndRange = max(result.EndRange, r.EndRange)
+        result.PrimesFound += r.PrimesFound
+        result.Primes = append(result.Primes, r.Primes...)
+    }
+    if len(ranges) > 1 {
+        result.Ranges = ranges
+    }
+    return result
+}
+
+// searchedRanges returns the ranges of a result with what is known about
+// how far each was searched
+func searchedRanges(result *Result) []RangeResult {
+    if len(result.Ranges) > 0 {
+        return result.Ranges
+    }
+    return []RangeResult{{
+        StartRange:        result.StartRange,
+        EndRange:          result.EndRange,
+        PrimesFound:       result.PrimesFound,
+        QuarantinedChunks: result.QuarantinedChunks,
+        UnsearchedChunks:  result.UnsearchedChunks,
+        DeadlineReached:   result.DeadlineReached,
+        CompletePrefix:    result.CompletePrefix,
+        LimitReached:      result.LimitReached,
+        Primes:            result.Primes,
+    }}
+}
+
+// resultCoverage returns the numbers a result searched in full, as merged
+// ascending ranges. A range stopped by -soft-deadline or -limit counts only
+// up to its complete prefix, and unsearched chunks (which a cancelled search
+// lists too) and quarantined chunks are left out.
+func resultCoverage(result *Result) [][2]int {
+    var covered [][2]int
+    for _, r := range searchedRanges(result) {
+        searched := [][2]int{{r.StartRange, r.EndRange}}
+        switch {
+        case r.CompletePrefix != nil:
+            searched = [][2]int{*r.CompletePrefix}
+        case r.DeadlineReached || r.LimitReached:
+            continue
+        }
+        covered = append(covered, subtractRanges(searched, r.UnsearchedChunks, r.QuarantinedChunks)...)
+    }
+    return mergeRanges(covered)
+}
+
+// resultPrimes returns every prime a result saved, or false if some range
+// found primes without saving them or only some primes were kept
+func resultPrimes(result *Result) ([]int, bool) {
+    if result.Stride > 1 || result.Transforms != "" {
+        return nil, false
+    }
+    primes := []int{}
+    for _, r := range searchedRanges(result) {
+        if r.PrimesFound > 0 && len(r.Primes) == 0 {
+            return nil, false
+        }
+        primes = append(primes, r.Primes...)
+    }
+    return primes, true
+}
+
+// mergeRanges sorts ranges by start and joins those that overlap or touch
+func mergeRanges(ranges [][2]int) [][2]int {
+    sort.Slice(ranges, func(i, j int) bool { return ranges[i][0] < ranges[j][0] })
+    merged := [][2]int{}
+    for _, r := range ranges {
+        if n := len(merged); n > 0 && r[0] <= merged[n-1][1]+1 {
+            merged[n-1][1] = max(merged[n-1][1], r[1])
+            continue
+        }
+        merged = append(merged, r)
+    }
+    return merged
+}
+
+// rangeDifference returns the parts of range a that are not covered by range b
+func rangeDifference(a, b [2]int) [][2]int {
+    diff := [][2]int{}
+    if a[0] > a[1] {
+        return diff
+    }
+    if b[0] > b[1] || b[1] < a[0] || b[0] > a[1] {
+        return append(diff, a)
+    }
+    if a[0] < b[0] {
+        diff = append(diff, [2]int{a[0], b[0] - 1})
+    }
+    if a[1] > b[1] {
+        diff = append(diff, [2]int{b[1] + 1, a[1]})
+    }
+    return diff
+}
+
+// subtractRanges returns the parts of ranges that none of the removed
+// ranges cover
+func subtractRanges(ranges [][2]int, removed ...[][2]int) [][2]int {
+    for _, rs := range removed {
+        for _, b := range rs {
+            var rest [][2]int
+            for _, a := range ranges {
+                rest = append(rest, rangeDifference(a, b)...)
+            }
+            ranges = rest
+        }
+    }
+    return mergeRanges(ranges)
+}
+
+// primeSetDifference returns the primes in a that are not in b, ascending
+func primeSetDifference(a, b []int) []int {
+    seen := make(map[int]bool, len(b))
+    for _, p := range b {
+        seen[p] = true
+    }
+    var diff []int
+    for _, p := range a {
+        if !seen[p] {
+            diff = append(diff, p)
+        }
+    }
+    sort.Ints(diff)
+    return diff
+}
+
+// computeDelta compares two results range by range: coverage counts only
+// what each searched in full (see resultCoverage), and prime sets are only
+// compared when both results saved all their primes with -save-primes.
+func computeDelta(old, new *Result) Delta {
+    oldCoverage, newCoverage := resultCoverage(old), resultCoverage(new)
+    delta := Delta{
+        OldRange:        [2]int{old.StartRange, old.EndRange},
+        NewRange:        [2]int{new.StartRange, new.EndRange},
+        AddedCoverage:   subtractRanges(newCoverage, oldCoverage),
+        RemovedCoverage: subtractRanges(oldCoverage, newCoverage),
+        CountChange:     new.PrimesFound - old.PrimesFound,
+    }
+    
+    oldPrimes, oldSaved := resultPrimes(old)
+    newPrimes, newSaved := resultPrimes(new)
+    if oldSaved && newSaved {
+        delta.PrimesCompared = true
+        delta.PrimesAdded = primeSetDifference(newPrimes, oldPrimes)
+        delta.PrimesRemoved = primeSetDifference(oldPrimes, newPrimes)
+    }
+    return delta
+}
+
+// runDelta implements `delta old-results new-results`
+func runDelta(args []string) error {
+    fs := flag.NewFlagSet("delta", flag.ExitOnError)
+    output := fs.String("output", "", "Output file (default stdout)")
+    fs.Usage = func() {
+        fmt.Fprintln(fs.Output(), "Usage: delta [-output file] old-results new-results (JSON or -format=bin)")
+        fs.PrintDefaults()
+    }
+    if err := parseFlags(fs, args); err != nil {
+        return err
+    }
+    
+    if fs.NArg() != 2 {
+        fs.Usage()
+        return fmt.Errorf("%w: delta needs exactly two result files", primefinder.ErrInvalidArgument)
+    }
+    
+    old, err := loadResult(fs.Arg(0))
+    if err != nil {
+        return err
+    }
+    new, err := loadResult(fs.Arg(1))
+    if err != nil {
+        return err
+    }
+    
+    delta := computeDelta(old, new)
+    if !delta.PrimesCompared {
+        fmt.Fprintln(os.Stderr, tr("Note: prime lists not compared; rerun both with -save-primes for a set difference"))
+    }
+    
+    out := os.Stdout
+    if *output != "" {
+        file, err := os.Create(*output)
+        if err != nil {
+            return fmt.Errorf("%w: %v", primefinder.ErrSinkWrite, err)
+        }
+        defer file.Close()
+        out = file
+    }
+    
+    encoder := json.NewEncoder(out)
+    encoder.SetIndent("", "  ")
+    return encoder.Encode(delta)
+}
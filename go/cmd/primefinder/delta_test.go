@@ -0,0 +1,138 @@
+// delta_test.go
+package main
+
+import (
+    "os"
+    "path/filepath"
+    "reflect"
+    "strings"
+    "testing"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+func TestRangeDifference(t *testing.T) {
+    tests := []struct {
+        a, b     [2]int
+        expected [][2]int
+    }{
+        {[2]int{1, 100}, [2]int{1, 100}, [][2]int{}},
+        {[2]int{1, 200}, [2]int{1, 100}, [][2]int{{101, 200}}},
+        {[2]int{1, 100}, [2]int{20, 50}, [][2]int{{1, 19}, {51, 100}}},
+        {[2]int{1, 10}, [2]int{20, 30}, [][2]int{{1, 10}}},
+    }
+    
+    for _, tt := range tests {
+        if got := rangeDifference(tt.a, tt.b); !reflect.DeepEqual(got, tt.expected) {
+            t.Errorf("rangeDifference(%v, %v) = %v, expected %v", tt.a, tt.b, got, tt.expected)
+        }
+    }
+}
+
+func TestComputeDelta(t *testing.T) {
+    old := &Result{StartRange: 1, EndRange: 10, PrimesFound: 4, Primes: []int{2, 3, 5, 7}}
+    new := &Result{StartRange: 1, EndRange: 20, PrimesFound: 8, Primes: []int{2, 3, 5, 7, 11, 13, 17, 19}}
+    
+    delta := computeDelta(old, new)
+    if !delta.PrimesCompared {
+        t.Fatal("Expected prime lists to be compared")
+    }
+    if !reflect.DeepEqual(delta.PrimesAdded, []int{11, 13, 17, 19}) {
+        t.Errorf("Unexpected primes added: %v", delta.PrimesAdded)
+    }
+    if len(delta.PrimesRemoved) != 0 {
+        t.Errorf("Expected no primes removed, got %v", delta.PrimesRemoved)
+    }
+    if delta.CountChange != 4 {
+        t.Errorf("Expected count change 4, got %d", delta.CountChange)
+    }
+    
+    // Without saved primes only coverage and counts are compared
+    old.Primes = nil
+    if delta = computeDelta(old, new); delta.PrimesCompared {
+        t.Error("Expected prime lists not to be compared without saved primes")
+    }
+}
+
+func TestComputeDeltaRanges(t *testing.T) {
+    // -ranges 1..100,1000..1100 against -start 1 -end 1100, both saved
+    all := primefinder.FindRange(1, 1100)
+    low, high := primefinder.FindRange(1, 100), primefinder.FindRange(1000, 1100)
+    old := &Result{RunID: "a", StartRange: 1, EndRange: 1100, PrimesFound: len(low) + len(high), Ranges: []RangeResult{
+        {StartRange: 1, EndRange: 100, PrimesFound: len(low), Primes: low},
+        {StartRange: 1000, EndRange: 1100, PrimesFound: len(high), Primes: high},
+    }}
+    new := &Result{RunID: "b", StartRange: 1, EndRange: 1100, PrimesFound: len(all), Primes: all}
+    
+    delta := computeDelta(old, new)
+    if !reflect.DeepEqual(delta.AddedCoverage, [][2]int{{101, 999}}) || len(delta.RemovedCoverage) != 0 {
+        t.Errorf("coverage added %v, removed %v; expected [[101 999]] added", delta.AddedCoverage, delta.RemovedCoverage)
+    }
+    if expected := primefinder.FindRange(101, 999); !delta.PrimesCompared || !reflect.DeepEqual(delta.PrimesAdded, expected) {
+        t.Errorf("compared %v, primes added %v, expected %v", delta.PrimesCompared, delta.PrimesAdded, expected)
+    }
+    
+    // Parts not searched in full are not coverage
+    tests := []struct {
+        name     string
+        partial  Result
+        expected [][2]int
+    }{
+        {"unsearched and quarantined", Result{StartRange: 1, EndRange: 1100, UnsearchedChunks: [][2]int{{601, 1100}}, QuarantinedChunks: [][2]int{{201, 300}}},
+            [][2]int{{1, 200}, {301, 600}}},
+        {"deadline prefix", Result{StartRange: 1, EndRange: 1100, DeadlineReached: true, CompletePrefix: &[2]int{1, 400}, UnsearchedChunks: [][2]int{{401, 1100}}},
+            [][2]int{{1, 400}}},
+        {"deadline without prefix", Result{StartRange: 1, EndRange: 1100, DeadlineReached: true}, [][2]int{}},
+        {"limit", Result{StartRange: 1, EndRange: 1100, LimitReached: true, CompletePrefix: &[2]int{1, 29}}, [][2]int{{1, 29}}},
+        {"cancelled range of several", Result{Cancelled: true, Ranges: []RangeResult{
+            {StartRange: 1, EndRange: 100},
+            {StartRange: 1000, EndRange: 1100, UnsearchedChunks: [][2]int{{1000, 1100}}},
+        }}, [][2]int{{1, 100}}},
+    }
+    for _, tt := range tests {
+        if got := resultCoverage(&tt.partial); !reflect.DeepEqual(got, tt.expected) {
+            t.Errorf("%s: covered %v, expected %v", tt.name, got, tt.expected)
+        }
+    }
+}
+
+func TestLoadResult(t *testing.T) {
+    dir := t.TempDir()
+    result := Result{RunID: "r", StartRange: 1, EndRange: 20, PrimesFound: 8, Primes: []int{2, 3, 5, 7, 11, 13, 17, 19}}
+    write := func(name string, w OutputWriter) string {
+        path := filepath.Join(dir, name)
+        file, err := os.Create(path)
+        if err != nil {
+            t.Fatal(err)
+        }
+        defer file.Close()
+        if err := w.WriteResult(file, result); err != nil {
+            t.Fatal(err)
+        }
+        return path
+    }
+    
+    for _, path := range []string{write("r.json", jsonWriter{}), write("r.bin", binaryWriter{})} {
+        got, err := loadResult(path)
+        if err != nil {
+            t.Fatalf("%s: %v", path, err)
+        }
+        if got.StartRange != 1 || got.EndRange != 20 || got.PrimesFound != 8 || !reflect.DeepEqual(got.Primes, result.Primes) {
+            t.Errorf("%s: loaded %+v", path, got)
+        }
+    }
+    
+    tests := []struct {
+        path string
+        err  string
+    }{
+        {write("r.csv", csvWriter{}), "-format=csv"},
+        {write("r.ndjson", ndjsonWriter{}), "-format=ndjson"},
+        {write("compat.json", jsonWriter{compat: "js"}), "-json-compat"},
+    }
+    for _, tt := range tests {
+        if _, err := loadResult(tt.path); err == nil || !strings.Contains(err.Error(), tt.err) {
+            t.Errorf("%s: got %v, expected an error naming %s", tt.path, err, tt.err)
+        }
+    }
+}
@@ -0,0 +1,46 @@
+// errors.go
+package main
+
+import (
+    "errors"
+    "fmt"
+    "os"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+// exitCodes maps each error kind to the process exit status reported for it.
+// Anything else exits with 1.
+var exitCodes = []struct {
+    err  error
+    code int
+}{
+    {primefinder.ErrInvalidArgument, 2},
+    {primefinder.ErrInvalidRange, 2},
+    {primefinder.ErrSinkWrite, 3},
+    {primefinder.ErrWorkerLost, 4},
+    {primefinder.ErrCancelled, 130}, // as for a shell job stopped by SIGINT
+}
+
+// exitCode returns the exit status for err
+func exitCode(err error) int {
+    if err == nil {
+        return 0
+    }
+    for _, e := range exitCodes {
+        if errors.Is(err, e.err) {
+            return e.code
+        }
+    }
+    return 1
+}
+
+// exitWithError prints err and exits with its mapped status. A lost worker
+// means a panic, so a diagnostic bundle is written for it.
+func exitWithError(err error) {
+    fmt.Fprintln(os.Stderr, tr("Error: %v", err))
+    if errors.Is(err, primefinder.ErrWorkerLost) {
+        reportCrash(err.Error(), nil)
+    }
+    os.Exit(exitCode(err))
+}
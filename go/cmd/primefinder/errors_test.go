@@ -0,0 +1,30 @@
+// errors_test.go
+package main
+
+import (
+    "errors"
+    "fmt"
+    "testing"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+func TestExitCode(t *testing.T) {
+    tests := []struct {
+        err  error
+        code int
+    }{
+        {nil, 0},
+        {errors.New("other"), 1},
+        {fmt.Errorf("wrapped: %w", primefinder.ErrInvalidRange), 2},
+        {primefinder.ErrInvalidArgument, 2},
+        {fmt.Errorf("results.json: %w", primefinder.ErrSinkWrite), 3},
+        {primefinder.ErrWorkerLost, 4},
+        {primefinder.ErrCancelled, 130},
+    }
+    for _, tt := range tests {
+        if got := exitCode(tt.err); got != tt.code {
+            t.Errorf("exitCode(%v) = %d, expected %d", tt.err, got, tt.code)
+        }
+    }
+}
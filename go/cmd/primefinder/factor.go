@@ -0,0 +1,98 @@
+// factor.go
+package main
+
+import (
+    "flag"
+    "fmt"
+    "os"
+    "runtime"
+    "sync"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+// Factorization is the prime factorization of one input of `factor`
+type Factorization struct {
+    N       uint64               `json:"n"`
+    Prime   bool                 `json:"prime"`
+    Factors []primefinder.Factor `json:"factors"`
+}
+
+// parseFactorInput parses an input of `factor`: a number below 2^64 written
+// as for -big-start, such as 2^64-59, or as for numberFlag, such as 1e12+39
+func parseFactorInput(s string) (uint64, error) {
+    if n, err := parseBigNumber(s); err == nil && n.Sign() >= 0 && n.IsUint64() {
+        return n.Uint64(), nil
+    }
+    if n, err := parseNumber(s); err == nil && n >= 0 {
+        return uint64(n), nil
+    }
+    return 0, fmt.Errorf("%w: %q is not a number in [0, 2^64)", primefinder.ErrInvalidArgument, s)
+}
+
+// factorAll factorizes the inputs concurrently, keeping their order
+func factorAll(inputs []uint64, workers int) []Factorization {
+    results := make([]Factorization, len(inputs))
+    jobs := make(chan int, workers)
+    var wg sync.WaitGroup
+    for w := 0; w < workers; w++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for i := range jobs {
+                factors := primefinder.Factorize(inputs[i])
+                prime := len(factors) == 1 && factors[0].Exponent == 1
+                results[i] = Factorization{N: inputs[i], Prime: prime, Factors: factors}
+            }
+        }()
+    }
+    for i := range inputs {
+        jobs <- i
+    }
+    close(jobs)
+    wg.Wait()
+    return results
+}
+
+// runFactor implements `factor N [N...]`
+func runFactor(args []string) error {
+    fs := flag.NewFlagSet("factor", flag.ExitOnError)
+    workers := fs.Int("workers", runtime.NumCPU(), "Number of workers")
+    output := fs.String("output", "", "Output JSON file (default stdout)")
+    fs.Usage = func() {
+        fmt.Fprintln(fs.Output(), "Usage: factor [flags] N [N...]")
+        fs.PrintDefaults()
+    }
+    if err := parseFlags(fs, args); err != nil {
+        return err
+    }
+    
+    if fs.NArg() == 0 {
+        fs.Usage()
+        return fmt.Errorf("%w: factor needs at least one N", primefinder.ErrInvalidArgument)
+    }
+    if *workers < 1 {
+        return fmt.Errorf("%w: workers must be at least 1, got %d", primefinder.ErrInvalidArgument, *workers)
+    }
+    inputs := make([]uint64, fs.NArg())
+    for i, arg := range fs.Args() {
+        n, err := parseFactorInput(arg)
+        if err != nil {
+            return err
+        }
+        inputs[i] = n
+    }
+    
+    results := factorAll(inputs, *workers)
+    
+    out := os.Stdout
+    if *output != "" {
+        file, err := os.Create(*output)
+        if err != nil {
+            return fmt.Errorf("%w: %v", primefinder.ErrSinkWrite, err)
+        }
+        defer file.Close()
+        out = file
+    }
+    return writeJSON(out, results, "")
+}
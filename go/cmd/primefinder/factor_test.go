@@ -0,0 +1,41 @@
+// factor_test.go
+package main
+
+import (
+    "reflect"
+    "testing"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+func TestFactorAll(t *testing.T) {
+    inputs := []uint64{12, 97, 1, 18446744073709551615, 97 * 97}
+    primes := []bool{false, true, false, false, false}
+    results := factorAll(inputs, 3)
+    for i, r := range results {
+        if r.N != inputs[i] || r.Prime != primes[i] || !reflect.DeepEqual(r.Factors, primefinder.Factorize(inputs[i])) {
+            t.Errorf("factorAll result %d = %+v, expected %d (prime: %v) factorized", i, r, inputs[i], primes[i])
+        }
+    }
+}
+
+func TestParseFactorInput(t *testing.T) {
+    tests := []struct {
+        in       string
+        expected uint64
+        wantErr  bool
+    }{
+        {"18446744073709551615", 1<<64 - 1, false},
+        {"1e12+39", 1_000_000_000_039, false},
+        {"2^64-59", 18446744073709551557, false},
+        {"-5", 0, true},
+        {"abc", 0, true},
+        {"2^64", 0, true},
+    }
+    for _, tt := range tests {
+        got, err := parseFactorInput(tt.in)
+        if got != tt.expected || (err != nil) != tt.wantErr {
+            t.Errorf("parseFactorInput(%q) = %d, %v; expected %d", tt.in, got, err, tt.expected)
+        }
+    }
+}
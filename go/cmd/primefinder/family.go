@@ -0,0 +1,88 @@
+// family.go
+package main
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "os"
+    "os/signal"
+    "syscall"
+    "time"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+// FamilyProgress is the progress of a proth or genfermat scan over a family
+// parameter. It is embedded in the subcommand's checkpoint, which doubles as
+// its result file.
+type FamilyProgress struct {
+    TestedThrough int     `json:"tested_through"`
+    Primes        []int   `json:"primes"`
+    TimedOut      []int   `json:"timed_out,omitempty"`
+    ExecutionTime float64 `json:"execution_time_seconds"`
+    Cancelled     bool    `json:"cancelled,omitempty"`
+}
+
+// familyScan runs one family scan
+type familyScan func(ctx context.Context, from int, onTested func(primefinder.FamilyResult)) (int, error)
+
+// scanResumable runs scan from the first parameter not yet tested, recording
+// results in progress. state, which embeds progress, is saved to checkpoint
+// every interval and when the scan ends, if checkpoint is set. Primes are
+// printed as they are found, named by name. SIGINT or SIGTERM stops the scan.
+func scanResumable(progress *FamilyProgress, state interface{}, checkpoint string, interval time.Duration, name func(int) string, scan familyScan) error {
+    ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+    defer stop()
+    
+    started, prior := time.Now(), progress.ExecutionTime
+    lastSave := started
+    var saveErr error
+    save := func() {
+        progress.ExecutionTime = prior + time.Since(started).Seconds()
+        if err := saveState(checkpoint, state); err != nil && saveErr == nil {
+            saveErr = err
+        }
+        lastSave = time.Now()
+    }
+    
+    _, err := scan(ctx, progress.TestedThrough+1, func(r primefinder.FamilyResult) {
+        progress.TestedThrough = r.X
+        switch {
+        case r.Prime:
+            progress.Primes = append(progress.Primes, r.X)
+            fmt.Println(tr("%s is prime", name(r.X)))
+        case r.TimedOut:
+            progress.TimedOut = append(progress.TimedOut, r.X)
+        }
+        if checkpoint != "" && time.Since(lastSave) >= interval {
+            save()
+        }
+    })
+    progress.ExecutionTime = prior + time.Since(started).Seconds()
+    progress.Cancelled = errors.Is(err, primefinder.ErrCancelled)
+    if err != nil && !progress.Cancelled {
+        return err
+    }
+    if checkpoint != "" {
+        save()
+        if saveErr != nil {
+            return fmt.Errorf("%s: %w", checkpoint, saveErr)
+        }
+    }
+    return err
+}
+
+// saveResult writes a subcommand's result as JSON to path
+func saveResult(path string, result interface{}) error {
+    file, err := os.Create(path)
+    if err != nil {
+        return fmt.Errorf("%w: %v", primefinder.ErrSinkWrite, err)
+    }
+    defer file.Close()
+    if err := writeJSON(file, result, ""); err != nil {
+        return fmt.Errorf("%w: %s: %v", primefinder.ErrSinkWrite, path, err)
+    }
+    fmt.Println(tr("Results saved to %s", path))
+    return nil
+}
@@ -0,0 +1,57 @@
+// family_test.go
+package main
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "path/filepath"
+    "reflect"
+    "testing"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+// fakeScan reports every x from the first untested one to stop as tested,
+// the even ones prime and multiples of 5 timed out
+func fakeScan(stop, last int) familyScan {
+    return func(ctx context.Context, from int, onTested func(primefinder.FamilyResult)) (int, error) {
+        for x := from; x <= stop; x++ {
+            onTested(primefinder.FamilyResult{X: x, Prime: x%2 == 0, TimedOut: x%5 == 0 && x%2 != 0})
+        }
+        if stop < last {
+            return stop, fmt.Errorf("%w: stopped at %d", primefinder.ErrCancelled, stop)
+        }
+        return stop, nil
+    }
+}
+
+func TestScanResumable(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "scan.json")
+    name := func(x int) string { return fmt.Sprint(x) }
+    
+    // An interrupted scan saves what it tested
+    run := ProthRun{K: 3, NMin: 1, NMax: 10}
+    run.TestedThrough = 0
+    err := scanResumable(&run.FamilyProgress, &run, path, 0, name, fakeScan(6, 10))
+    if !errors.Is(err, primefinder.ErrCancelled) || !run.Cancelled {
+        t.Fatalf("interrupted scan: %v, cancelled=%v", err, run.Cancelled)
+    }
+    
+    // Resuming continues after the saved prefix
+    var resumed ProthRun
+    if err := loadState(path, &resumed); err != nil {
+        t.Fatal(err)
+    }
+    if resumed.TestedThrough != 6 || resumed.K != 3 {
+        t.Fatalf("saved state %+v, expected n tested through 6", resumed)
+    }
+    if err := scanResumable(&resumed.FamilyProgress, &resumed, path, 0, name, fakeScan(10, 10)); err != nil {
+        t.Fatal(err)
+    }
+    expected := FamilyProgress{TestedThrough: 10, Primes: []int{2, 4, 6, 8, 10}, TimedOut: []int{5}}
+    resumed.ExecutionTime = 0
+    if !reflect.DeepEqual(resumed.FamilyProgress, expected) {
+        t.Errorf("resumed scan %+v, expected %+v", resumed.FamilyProgress, expected)
+    }
+}
@@ -0,0 +1,78 @@
+// features.go
+package main
+
+import (
+    "fmt"
+    "os"
+    "sort"
+    "strings"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+// featuresEnv names the environment variable that switches features on for
+// every run, in addition to -features
+const featuresEnv = "PRIME_FINDER_FEATURES"
+
+// experiments lists the features that ship dark, with what each unlocks.
+// Options gated by a feature are rejected unless it is switched on.
+var experiments = map[string]string{
+    "thread-executor": "-executor=thread, one locked OS thread per worker",
+}
+
+// featureSet is the set of experimental features switched on for a run
+type featureSet map[string]bool
+
+// parseFeatures merges comma-separated feature lists, rejecting names that
+// are not in experiments
+func parseFeatures(lists ...string) (featureSet, error) {
+    set := featureSet{}
+    for _, list := range lists {
+        for _, name := range strings.Split(list, ",") {
+            name = strings.TrimSpace(name)
+            if name == "" {
+                continue
+            }
+            if _, ok := experiments[name]; !ok {
+                return nil, fmt.Errorf("%w: unknown feature %q (available: %s)", primefinder.ErrInvalidArgument, name, strings.Join(experimentNames(), ", "))
+            }
+            set[name] = true
+        }
+    }
+    return set, nil
+}
+
+// loadFeatures returns the features switched on by the environment and by
+// the -features value
+func loadFeatures(flagValue string) (featureSet, error) {
+    return parseFeatures(os.Getenv(featuresEnv), flagValue)
+}
+
+// experimentNames lists every known feature
+func experimentNames() []string {
+    var names []string
+    for name := range experiments {
+        names = append(names, name)
+    }
+    sort.Strings(names)
+    return names
+}
+
+// names lists the features switched on, for the result metadata
+func (f featureSet) names() []string {
+    var names []string
+    for name := range f {
+        names = append(names, name)
+    }
+    sort.Strings(names)
+    return names
+}
+
+// require returns an error unless the feature is switched on
+func (f featureSet) require(name string) error {
+    if f[name] {
+        return nil
+    }
+    return fmt.Errorf("%w: %s is experimental; enable it with -features=%s or %s=%s",
+        primefinder.ErrInvalidArgument, experiments[name], name, featuresEnv, name)
+}
@@ -0,0 +1,44 @@
+// features_test.go
+package main
+
+import (
+    "errors"
+    "reflect"
+    "testing"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+func TestParseFeatures(t *testing.T) {
+    tests := []struct {
+        lists    []string
+        expected []string
+        err      error
+    }{
+        {[]string{"", ""}, nil, nil},
+        {[]string{"thread-executor", ""}, []string{"thread-executor"}, nil},
+        {[]string{"", " thread-executor, "}, []string{"thread-executor"}, nil},
+        {[]string{"thread-executor", "thread-executor"}, []string{"thread-executor"}, nil},
+        {[]string{"", "warp-drive"}, nil, primefinder.ErrInvalidArgument},
+    }
+    for _, tt := range tests {
+        set, err := parseFeatures(tt.lists...)
+        if !errors.Is(err, tt.err) {
+            t.Errorf("parseFeatures(%q) error = %v, expected %v", tt.lists, err, tt.err)
+            continue
+        }
+        if err == nil && !reflect.DeepEqual(set.names(), tt.expected) {
+            t.Errorf("parseFeatures(%q) = %v, expected %v", tt.lists, set.names(), tt.expected)
+        }
+    }
+}
+
+func TestFeatureRequire(t *testing.T) {
+    set := featureSet{"thread-executor": true}
+    if err := set.require("thread-executor"); err != nil {
+        t.Errorf("Enabled feature rejected: %v", err)
+    }
+    if err := (featureSet{}).require("thread-executor"); !errors.Is(err, primefinder.ErrInvalidArgument) {
+        t.Errorf("Disabled feature gave %v, expected ErrInvalidArgument", err)
+    }
+}
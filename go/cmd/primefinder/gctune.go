@@ -0,0 +1,115 @@
+// gctune.go
+package main
+
+import (
+    "fmt"
+    "runtime"
+    "runtime/debug"
+    "strconv"
+    "strings"
+    "time"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+// byteUnits maps size suffixes to multipliers, longest suffixes first
+var byteUnits = []struct {
+    suffix string
+    scale  int64
+}{
+    {"KiB", 1 << 10}, {"MiB", 1 << 20}, {"GiB", 1 << 30}, {"TiB", 1 << 40},
+    {"KB", 1e3}, {"MB", 1e6}, {"GB", 1e9}, {"TB", 1e12},
+    {"B", 1},
+}
+
+// parseByteSize parses sizes such as "512MiB", "2GB", or "1048576"
+func parseByteSize(s string) (int64, error) {
+    s = strings.TrimSpace(s)
+    scale := int64(1)
+    for _, u := range byteUnits {
+        if strings.HasSuffix(s, u.suffix) {
+            s, scale = strings.TrimSpace(strings.TrimSuffix(s, u.suffix)), u.scale
+            break
+        }
+    }
+    n, err := strconv.ParseInt(s, 10, 64)
+    if err != nil || n < 0 {
+        return 0, fmt.Errorf("invalid size %q", s)
+    }
+    return n * scale, nil
+}
+
+// gcSettings holds the garbage collector tuning requested on the command
+// line; empty strings leave the runtime defaults (and GOGC/GOMEMLIMIT) alone
+type gcSettings struct {
+    gogc        string // percentage or "off"
+    memoryLimit string // soft memory limit size
+    ballast     string // size of a heap ballast allocation
+}
+
+// apply configures the runtime and returns the ballast, which the caller must
+// keep reachable for the duration of the run
+func (s gcSettings) apply() ([]byte, error) {
+    switch s.gogc {
+    case "":
+    case "off":
+        debug.SetGCPercent(-1)
+    default:
+        percent, err := strconv.Atoi(s.gogc)
+        if err != nil || percent < 0 {
+            return nil, fmt.Errorf("%w: -gogc must be a non-negative percentage or off", primefinder.ErrInvalidArgument)
+        }
+        debug.SetGCPercent(percent)
+    }
+    
+    if s.memoryLimit != "" {
+        limit, err := parseByteSize(s.memoryLimit)
+        if err != nil {
+            return nil, fmt.Errorf("%w: -memory-limit: %v", primefinder.ErrInvalidArgument, err)
+        }
+        debug.SetMemoryLimit(limit)
+    }
+    
+    if s.ballast == "" {
+        return nil, nil
+    }
+    size, err := parseByteSize(s.ballast)
+    if err != nil {
+        return nil, fmt.Errorf("%w: -ballast: %v", primefinder.ErrInvalidArgument, err)
+    }
+    return make([]byte, size), nil
+}
+
+// gcSnapshot captures the collector statistics reported in verbose mode
+type gcSnapshot struct {
+    numGC      uint32
+    pauseTotal time.Duration
+    heapAlloc  uint64
+    sys        uint64
+}
+
+// takeGCSnapshot reads the current collector statistics
+func takeGCSnapshot() gcSnapshot {
+    var m runtime.MemStats
+    runtime.ReadMemStats(&m)
+    return gcSnapshot{
+        numGC:      m.NumGC,
+        pauseTotal: time.Duration(m.PauseTotalNs),
+        heapAlloc:  m.HeapAlloc,
+        sys:        m.Sys,
+    }
+}
+
+// printGCReport prints collector activity between two snapshots
+func printGCReport(before, after gcSnapshot) {
+    // SetGCPercent is the only way to read the current setting
+    percent := debug.SetGCPercent(-1)
+    debug.SetGCPercent(percent)
+    
+    fmt.Println(tr("GC: %d cycles, %v total pause, heap %d -> %d KiB, sys %d KiB (GOGC=%d)",
+        after.numGC-before.numGC,
+        after.pauseTotal-before.pauseTotal,
+        before.heapAlloc>>10, after.heapAlloc>>10,
+        after.sys>>10,
+        percent))
+}
@@ -0,0 +1,27 @@
+// gctune_test.go
+package main
+
+import "testing"
+
+func TestParseByteSize(t *testing.T) {
+    tests := []struct {
+        in       string
+        expected int64
+    }{
+        {"1024", 1024},
+        {"512MiB", 512 << 20},
+        {"2GB", 2e9},
+        {"64 KiB", 64 << 10},
+        {"10B", 10},
+    }
+    for _, tt := range tests {
+        if got, err := parseByteSize(tt.in); err != nil || got != tt.expected {
+            t.Errorf("parseByteSize(%q) = %d, %v; expected %d", tt.in, got, err, tt.expected)
+        }
+    }
+    for _, bad := range []string{"", "lots", "-5MB", "1.5GB"} {
+        if _, err := parseByteSize(bad); err == nil {
+            t.Errorf("parseByteSize(%q) succeeded, expected error", bad)
+        }
+    }
+}
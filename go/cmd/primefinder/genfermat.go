@@ -0,0 +1,76 @@
+// genfermat.go
+package main
+
+import (
+    "context"
+    "errors"
+    "flag"
+    "fmt"
+    "runtime"
+    "time"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+// GenFermatRun is the state and result of a genfermat scan
+type GenFermatRun struct {
+    M       int     `json:"m"`
+    BMin    int     `json:"b_min"`
+    BMax    int     `json:"b_max"`
+    Timeout float64 `json:"timeout_seconds,omitempty"`
+    FamilyProgress
+}
+
+// runGenFermat implements `genfermat`: test b^(2^m)+1 for each b in a range
+func runGenFermat(args []string) error {
+    fs := flag.NewFlagSet("genfermat", flag.ExitOnError)
+    m := fs.Int("m", 4, "Exponent m of b^(2^m)+1")
+    bMin := numberFlag(2)
+    bMax := numberFlag(1000)
+    fs.Var(&bMin, "b-min", "Smallest base b")
+    fs.Var(&bMax, "b-max", "Largest base b")
+    workers := fs.Int("workers", runtime.NumCPU(), "Number of workers, each testing one b at a time")
+    timeout := fs.Duration("timeout", 0, "Give up on a base after this long and record it as timed out (0 for no limit)")
+    checkpoint := fs.String("checkpoint", "", "Save progress to this file periodically so -resume can continue the scan")
+    interval := fs.Duration("checkpoint-interval", 30*time.Second, "Time between checkpoint saves")
+    resume := fs.Bool("resume", false, "Continue the scan saved in the -checkpoint file (its m, b range and timeout replace those given)")
+    output := fs.String("output", "genfermat.json", "Output file")
+    if err := parseFlags(fs, args); err != nil {
+        return err
+    }
+    
+    run := GenFermatRun{M: *m, BMin: int(bMin), BMax: int(bMax), Timeout: timeout.Seconds()}
+    run.TestedThrough = run.BMin - 1
+    if *resume {
+        if *checkpoint == "" {
+            return fmt.Errorf("%w: -resume needs -checkpoint", primefinder.ErrInvalidArgument)
+        }
+        run = GenFermatRun{}
+        if err := loadState(*checkpoint, &run); err != nil {
+            return err
+        }
+        fmt.Println(tr("Resuming from %s: tested b up to %d", *checkpoint, run.TestedThrough))
+    }
+    
+    fmt.Println(tr("Testing b^(2^%d)+1 for b in [%d, %d] with %d workers...", run.M, run.BMin, run.BMax, *workers))
+    name := func(b int) string { return fmt.Sprintf("%d^(2^%d)+1", b, run.M) }
+    perBase := time.Duration(run.Timeout * float64(time.Second))
+    err := scanResumable(&run.FamilyProgress, &run, *checkpoint, *interval, name,
+        func(ctx context.Context, from int, onTested func(primefinder.FamilyResult)) (int, error) {
+            if from > run.BMax {
+                return run.BMax, nil
+            }
+            return primefinder.FindGenFermat(ctx, run.M, from, run.BMax, *workers, perBase, onTested)
+        })
+    if err != nil && !errors.Is(err, primefinder.ErrCancelled) {
+        return err
+    }
+    fmt.Println(tr("Found %d primes in %v", len(run.Primes), time.Duration(run.ExecutionTime*float64(time.Second))))
+    if len(run.TimedOut) > 0 {
+        fmt.Println(tr("%d bases timed out", len(run.TimedOut)))
+    }
+    if saveErr := saveResult(*output, run); saveErr != nil {
+        return saveErr
+    }
+    return err
+}
@@ -0,0 +1,66 @@
+// goldbach.go
+package main
+
+import (
+    "flag"
+    "fmt"
+    "os"
+    "runtime"
+    "time"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+// GoldbachResult reports a Goldbach verification run
+type GoldbachResult struct {
+    StartRange    int     `json:"start_range"`
+    EndRange      int     `json:"end_range"`
+    Workers       int     `json:"workers"`
+    ExecutionTime float64 `json:"execution_time_seconds"`
+    primefinder.GoldbachReport
+}
+
+// runGoldbach implements `goldbach -end 1e9`
+func runGoldbach(args []string) error {
+    fs := flag.NewFlagSet("goldbach", flag.ExitOnError)
+    start := numberFlag(4)
+    end := numberFlag(1_000_000)
+    fs.Var(&start, "start", "Start of range (accepts 1e9 forms)")
+    fs.Var(&end, "end", "End of range (accepts 1e9 forms)")
+    workers := fs.Int("workers", runtime.NumCPU(), "Number of workers")
+    counts := fs.Bool("counts", false, fmt.Sprintf("Count every decomposition n = p + q of each number, listed in the result (end up to %d)", primefinder.MaxGoldbachCountEnd))
+    output := fs.String("output", "", "Output JSON file (default stdout)")
+    if err := parseFlags(fs, args); err != nil {
+        return err
+    }
+    
+    ctx, stop := signalContext()
+    defer stop()
+    startTime := time.Now()
+    report, err := primefinder.VerifyGoldbach(ctx, int(start), int(end), *workers, *counts)
+    if err != nil {
+        return err
+    }
+    duration := time.Since(startTime)
+    fmt.Fprintln(os.Stderr, tr("Verified %d even numbers in %v; the largest least prime needed was %d, for %d", report.Checked, duration, report.MaxLeast, report.MaxLeastAt))
+    for _, n := range report.Counterexamples {
+        fmt.Fprintln(os.Stderr, tr("*** COUNTEREXAMPLE: %d is not the sum of two primes ***", n))
+    }
+    
+    out := os.Stdout
+    if *output != "" {
+        file, err := os.Create(*output)
+        if err != nil {
+            return fmt.Errorf("%w: %v", primefinder.ErrSinkWrite, err)
+        }
+        defer file.Close()
+        out = file
+    }
+    return writeJSON(out, GoldbachResult{
+        StartRange:     int(start),
+        EndRange:       int(end),
+        Workers:        *workers,
+        ExecutionTime:  duration.Seconds(),
+        GoldbachReport: report,
+    }, "")
+}
@@ -0,0 +1,226 @@
+// i18n.go
+package main
+
+import (
+    "flag"
+    "fmt"
+    "os"
+    "sort"
+    "strings"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+// catalogs holds translations of user-facing messages, keyed by language
+// code and then by the English format string. A language is added by adding
+// its catalog here; messages missing from a catalog are printed in English.
+var catalogs = map[string]map[string]string{
+    "de": {
+        "Run %s: finding primes from %d to %d": "Lauf %s: suche Primzahlen von %d bis %d",
+        "Run %s: finding primes in %d ranges": "Lauf %s: suche Primzahlen in %d Bereichen",
+        "Running sequential version (%s)...": "Starte sequentielle Version (%s)...",
+        "Running concurrent version with %d workers (%s executor, %s)...": "Starte nebenläufige Version mit %d Workern (%s-Executor, %s)...",
+        "Running concurrent version with %d workers, autoscaled between 1 and %d (%s executor, %s)...": "Starte nebenläufige Version mit %d Workern, automatisch skaliert zwischen 1 und %d (%s-Executor, %s)...",
+        "Execution trace written to %s": "Ausführungs-Trace nach %s geschrieben",
+        "Found %d primes in %v": "%d Primzahlen in %v gefunden",
+        "Count matches known value pi = %d": "Anzahl stimmt mit dem bekannten Wert pi = %d überein",
+        "*** MISMATCH: found %d primes but the known count for this range is %d ***": "*** ABWEICHUNG: %d Primzahlen gefunden, die bekannte Anzahl für diesen Bereich ist aber %d ***",
+        "Warning: %d chunks quarantined after repeated timeouts; primes in them are missing:": "Warnung: %d Blöcke nach wiederholten Zeitüberschreitungen in Quarantäne; ihre Primzahlen fehlen:",
+        "Transforms emitted %d primes": "Transformationen gaben %d Primzahlen aus",
+        "Found %d %s prime pairs": "%d Primzahlpaare (%s) gefunden",
+        "Found %d %s prime pairs in %v": "%d Primzahlpaare (%s) in %v gefunden",
+        "Largest gap %d between %d and %d, average gap %.2f": "Größte Lücke %d zwischen %d und %d, mittlere Lücke %.2f",
+        "Verified %d even numbers in %v; the largest least prime needed was %d, for %d": "%d gerade Zahlen in %v geprüft; die größte benötigte kleinste Primzahl war %d, für %d",
+        "*** COUNTEREXAMPLE: %d is not the sum of two primes ***": "*** GEGENBEISPIEL: %d ist nicht die Summe zweier Primzahlen ***",
+        "Timings: %s": "Zeiten: %s",
+        "Hint: %s": "Hinweis: %s",
+        "planning consumed %.0f%% of wall time — the range may be too small to gain from workers; consider -sequential": "die Planung beanspruchte %.0f%% der Laufzeit — der Bereich ist womöglich zu klein, um von Workern zu profitieren; -sequential erwägen",
+        "dispatching chunks consumed %.0f%% of wall time — chunks are small; consider -scheduler static": "das Verteilen der Blöcke beanspruchte %.0f%% der Laufzeit — die Blöcke sind klein; -scheduler static erwägen",
+        "merging chunk results consumed %.0f%% of wall time — consider -unordered, or -count-only if the primes are not needed": "das Zusammenführen der Blockergebnisse beanspruchte %.0f%% der Laufzeit — -unordered erwägen, oder -count-only, wenn die Primzahlen nicht gebraucht werden",
+        "output encoding consumed %.0f%% of wall time — consider -format=bin": "das Kodieren der Ausgabe beanspruchte %.0f%% der Laufzeit — -format=bin erwägen",
+        "output encoding consumed %.0f%% of wall time — consider -count-only if the primes are not needed": "das Kodieren der Ausgabe beanspruchte %.0f%% der Laufzeit — -count-only erwägen, wenn die Primzahlen nicht gebraucht werden",
+        "writing the output consumed %.0f%% of wall time — consider -format=bin or a faster disk": "das Schreiben der Ausgabe beanspruchte %.0f%% der Laufzeit — -format=bin oder einen schnelleren Datenträger erwägen",
+        "Total: %d primes in %d ranges (%.4gs)": "Gesamt: %d Primzahlen in %d Bereichen (%.4gs)",
+        "Results saved to %s": "Ergebnisse in %s gespeichert",
+        "Search cancelled; writing partial results": "Suche abgebrochen; schreibe Teilergebnisse",
+        "Warning: %d ranges were not searched before cancellation:": "Warnung: %d Bereiche wurden vor dem Abbruch nicht durchsucht:",
+        "Resuming from %s: searched up to %d, %d primes found": "Setze fort aus %s: durchsucht bis %d, %d Primzahlen gefunden",
+        "Checkpoint saved to %s: searched up to %d": "Checkpoint in %s gespeichert: durchsucht bis %d",
+        "Soft deadline reached; [%d, %d] is complete with %d primes": "Weiche Frist erreicht; [%d, %d] ist vollständig mit %d Primzahlen",
+        "Soft deadline reached before any prefix of the range was complete": "Weiche Frist erreicht, bevor ein Anfangsstück des Bereichs vollständig war",
+        "Warning: %d ranges were not searched before the soft deadline:": "Warnung: %d Bereiche wurden vor der weichen Frist nicht durchsucht:",
+        "Limit of %d primes reached at %d": "Grenze von %d Primzahlen bei %d erreicht",
+        "%s is prime": "%s ist prim",
+        "Run %s: testing 2^p-1 for prime p up to %d with %d workers...": "Lauf %s: teste 2^p-1 für Primzahlen p bis %d mit %d Workern...",
+        "2^%d-1 is prime (%d digits)": "2^%d-1 ist prim (%d Stellen)",
+        "Found %d Mersenne primes among %d prime exponents in %v": "%d Mersenne-Primzahlen unter %d primen Exponenten in %v gefunden",
+        "Resuming from %s: tested n up to %d": "Setze fort aus %s: n bis %d getestet",
+        "Testing %d*2^n+1 for n in [%d, %d] with %d workers...": "Teste %d*2^n+1 für n in [%d, %d] mit %d Workern...",
+        "Resuming from %s: tested b up to %d": "Setze fort aus %s: b bis %d getestet",
+        "Testing b^(2^%d)+1 for b in [%d, %d] with %d workers...": "Teste b^(2^%d)+1 für b in [%d, %d] mit %d Workern...",
+        "%d bases timed out": "%d Basen haben das Zeitlimit überschritten",
+        "Admin endpoint listening on http://%s": "Admin-Endpunkt lauscht auf http://%s",
+        "Serving %d primes in %d ranges from %s on %s": "Stelle %d Primzahlen in %d Bereichen aus %s auf %s bereit",
+        "Error: %v": "Fehler: %v",
+        "Run %s: finding probable primes from %s to %s": "Lauf %s: suche wahrscheinliche Primzahlen von %s bis %s",
+        "Running arbitrary precision version with %d workers...": "Starte Version mit beliebiger Genauigkeit mit %d Workern...",
+        "Found %d probable primes in %v": "%d wahrscheinliche Primzahlen in %v gefunden",
+        "Diagnostic bundle written to %s; please attach it to a bug report": "Diagnosepaket nach %s geschrieben; bitte einem Fehlerbericht beifügen",
+        "Diagnostic bundle written to %s": "Diagnosepaket nach %s geschrieben",
+        "Note: prime lists not compared; rerun both with -save-primes for a set difference": "Hinweis: Primzahllisten nicht verglichen; beide mit -save-primes erneut ausführen, um eine Mengendifferenz zu erhalten",
+        "GC: %d cycles, %v total pause, heap %d -> %d KiB, sys %d KiB (GOGC=%d)": "GC: %d Zyklen, %v Pausen insgesamt, Heap %d -> %d KiB, System %d KiB (GOGC=%d)",
+        "Profiles written to %s and %s": "Profile nach %s und %s geschrieben",
+        "# seed %d": "# Startwert %d",
+        "PASS": "OK",
+        "FAIL": "FEHLER",
+        "All %d self-test checks passed": "Alle %d Selbsttests bestanden",
+        "Simulating %d chunks on %d workers (total work %.4gs)": "Simuliere %d Blöcke auf %d Workern (Gesamtarbeit %.4gs)",
+        "Skipping %s: %s": "Überspringe %s: %s",
+    },
+    "es": {
+        "Run %s: finding primes from %d to %d": "Ejecución %s: buscando primos de %d a %d",
+        "Run %s: finding primes in %d ranges": "Ejecución %s: buscando primos en %d rangos",
+        "Running sequential version (%s)...": "Ejecutando la versión secuencial (%s)...",
+        "Running concurrent version with %d workers (%s executor, %s)...": "Ejecutando la versión concurrente con %d trabajadores (ejecutor %s, %s)...",
+        "Running concurrent version with %d workers, autoscaled between 1 and %d (%s executor, %s)...": "Ejecutando la versión concurrente con %d trabajadores, escalados automáticamente entre 1 y %d (ejecutor %s, %s)...",
+        "Execution trace written to %s": "Traza de ejecución escrita en %s",
+        "Found %d primes in %v": "Se encontraron %d primos en %v",
+        "Count matches known value pi = %d": "El recuento coincide con el valor conocido pi = %d",
+        "*** MISMATCH: found %d primes but the known count for this range is %d ***": "*** DISCREPANCIA: se encontraron %d primos pero el recuento conocido para este rango es %d ***",
+        "Warning: %d chunks quarantined after repeated timeouts; primes in them are missing:": "Aviso: %d bloques en cuarentena tras agotar repetidamente el tiempo; faltan sus primos:",
+        "Transforms emitted %d primes": "Las transformaciones emitieron %d primos",
+        "Found %d %s prime pairs": "Se encontraron %d pares de primos (%s)",
+        "Found %d %s prime pairs in %v": "Se encontraron %d pares de primos (%s) en %v",
+        "Largest gap %d between %d and %d, average gap %.2f": "Mayor hueco %d entre %d y %d, hueco medio %.2f",
+        "Verified %d even numbers in %v; the largest least prime needed was %d, for %d": "Se verificaron %d números pares en %v; el mayor primo mínimo necesario fue %d, para %d",
+        "*** COUNTEREXAMPLE: %d is not the sum of two primes ***": "*** CONTRAEJEMPLO: %d no es la suma de dos primos ***",
+        "Timings: %s": "Tiempos: %s",
+        "Hint: %s": "Sugerencia: %s",
+        "planning consumed %.0f%% of wall time — the range may be too small to gain from workers; consider -sequential": "la planificación consumió el %.0f%% del tiempo total — puede que el rango sea demasiado pequeño para aprovechar los trabajadores; considere -sequential",
+        "dispatching chunks consumed %.0f%% of wall time — chunks are small; consider -scheduler static": "el reparto de bloques consumió el %.0f%% del tiempo total — los bloques son pequeños; considere -scheduler static",
+        "merging chunk results consumed %.0f%% of wall time — consider -unordered, or -count-only if the primes are not needed": "la combinación de resultados consumió el %.0f%% del tiempo total — considere -unordered, o -count-only si no necesita los primos",
+        "output encoding consumed %.0f%% of wall time — consider -format=bin": "la codificación de la salida consumió el %.0f%% del tiempo total — considere -format=bin",
+        "output encoding consumed %.0f%% of wall time — consider -count-only if the primes are not needed": "la codificación de la salida consumió el %.0f%% del tiempo total — considere -count-only si no necesita los primos",
+        "writing the output consumed %.0f%% of wall time — consider -format=bin or a faster disk": "la escritura de la salida consumió el %.0f%% del tiempo total — considere -format=bin o un disco más rápido",
+        "Total: %d primes in %d ranges (%.4gs)": "Total: %d primos en %d rangos (%.4gs)",
+        "Results saved to %s": "Resultados guardados en %s",
+        "Search cancelled; writing partial results": "Búsqueda cancelada; guardando resultados parciales",
+        "Warning: %d ranges were not searched before cancellation:": "Aviso: %d rangos no se buscaron antes de la cancelación:",
+        "Resuming from %s: searched up to %d, %d primes found": "Reanudando desde %s: buscado hasta %d, %d primos encontrados",
+        "Checkpoint saved to %s: searched up to %d": "Punto de control guardado en %s: buscado hasta %d",
+        "Soft deadline reached; [%d, %d] is complete with %d primes": "Plazo flexible alcanzado; [%d, %d] está completo con %d primos",
+        "Soft deadline reached before any prefix of the range was complete": "Plazo flexible alcanzado antes de completar ningún prefijo del rango",
+        "Warning: %d ranges were not searched before the soft deadline:": "Aviso: %d rangos no se buscaron antes del plazo flexible:",
+        "Limit of %d primes reached at %d": "Límite de %d primos alcanzado en %d",
+        "%s is prime": "%s es primo",
+        "Run %s: testing 2^p-1 for prime p up to %d with %d workers...": "Ejecución %s: probando 2^p-1 para p primo hasta %d con %d trabajadores...",
+        "2^%d-1 is prime (%d digits)": "2^%d-1 es primo (%d dígitos)",
+        "Found %d Mersenne primes among %d prime exponents in %v": "Se encontraron %d primos de Mersenne entre %d exponentes primos en %v",
+        "Resuming from %s: tested n up to %d": "Reanudando desde %s: n probado hasta %d",
+        "Testing %d*2^n+1 for n in [%d, %d] with %d workers...": "Probando %d*2^n+1 para n en [%d, %d] con %d trabajadores...",
+        "Resuming from %s: tested b up to %d": "Reanudando desde %s: b probado hasta %d",
+        "Testing b^(2^%d)+1 for b in [%d, %d] with %d workers...": "Probando b^(2^%d)+1 para b en [%d, %d] con %d trabajadores...",
+        "%d bases timed out": "%d bases superaron el tiempo límite",
+        "Admin endpoint listening on http://%s": "Punto de acceso de administración escuchando en http://%s",
+        "Serving %d primes in %d ranges from %s on %s": "Sirviendo %d primos en %d rangos desde %s en %s",
+        "Error: %v": "Error: %v",
+        "Run %s: finding probable primes from %s to %s": "Ejecución %s: buscando primos probables de %s a %s",
+        "Running arbitrary precision version with %d workers...": "Ejecutando la versión de precisión arbitraria con %d trabajadores...",
+        "Found %d probable primes in %v": "Se encontraron %d primos probables en %v",
+        "Diagnostic bundle written to %s; please attach it to a bug report": "Paquete de diagnóstico escrito en %s; adjúntelo a un informe de error",
+        "Diagnostic bundle written to %s": "Paquete de diagnóstico escrito en %s",
+        "Note: prime lists not compared; rerun both with -save-primes for a set difference": "Nota: listas de primos no comparadas; vuelva a ejecutar ambos con -save-primes para obtener la diferencia de conjuntos",
+        "GC: %d cycles, %v total pause, heap %d -> %d KiB, sys %d KiB (GOGC=%d)": "GC: %d ciclos, %v de pausa total, heap %d -> %d KiB, sistema %d KiB (GOGC=%d)",
+        "Profiles written to %s and %s": "Perfiles escritos en %s y %s",
+        "# seed %d": "# semilla %d",
+        "PASS": "OK",
+        "FAIL": "FALLO",
+        "All %d self-test checks passed": "Las %d comprobaciones de autoprueba pasaron",
+        "Simulating %d chunks on %d workers (total work %.4gs)": "Simulando %d bloques en %d trabajadores (trabajo total %.4gs)",
+        "Skipping %s: %s": "Omitiendo %s: %s",
+    },
+}
+
+// locale is the language messages are printed in; "" means English
+var locale = languageCode(firstNonEmpty(os.Getenv("LC_ALL"), os.Getenv("LC_MESSAGES"), os.Getenv("LANG")))
+
+// languageCode reduces a locale name such as de_DE.UTF-8 to the language
+// code of a known catalog, or "" if there is none
+func languageCode(name string) string {
+    lang := strings.ToLower(name)
+    if i := strings.IndexAny(lang, "_-.@"); i >= 0 {
+        lang = lang[:i]
+    }
+    if _, ok := catalogs[lang]; !ok {
+        return ""
+    }
+    return lang
+}
+
+// firstNonEmpty returns the first non-empty string
+func firstNonEmpty(values ...string) string {
+    for _, v := range values {
+        if v != "" {
+            return v
+        }
+    }
+    return ""
+}
+
+// setLocale selects the message language by name; en and C select English
+func setLocale(name string) error {
+    switch strings.ToLower(name) {
+    case "en", "c", "posix":
+        locale = ""
+        return nil
+    }
+    lang := languageCode(name)
+    if lang == "" {
+        return fmt.Errorf("unsupported language %q (available: en, %s)", name, strings.Join(availableLocales(), ", "))
+    }
+    locale = lang
+    return nil
+}
+
+// langUsage is the help text of -lang, shared by the search and every
+// subcommand
+const langUsage = "Language for progress and summary messages, e.g. de or es (default: from LC_ALL/LC_MESSAGES/LANG); result files, tables and error details stay in English"
+
+// applyLang selects the language named by -lang, if it was given
+func applyLang(lang string) error {
+    if lang == "" {
+        return nil
+    }
+    if err := setLocale(lang); err != nil {
+        return fmt.Errorf("%w: -lang: %v", primefinder.ErrInvalidArgument, err)
+    }
+    return nil
+}
+
+// parseFlags parses the flags of a subcommand, with -lang added to them
+func parseFlags(fs *flag.FlagSet, args []string) error {
+    lang := fs.String("lang", "", langUsage)
+    fs.Parse(args)
+    return applyLang(*lang)
+}
+
+// availableLocales lists the translated languages
+func availableLocales() []string {
+    var langs []string
+    for lang := range catalogs {
+        langs = append(langs, lang)
+    }
+    sort.Strings(langs)
+    return langs
+}
+
+// tr formats a message in the selected language and records it for
+// diagnostic bundles
+func tr(format string, args ...interface{}) string {
+    if translated, ok := catalogs[locale][format]; ok {
+        format = translated
+    }
+    msg := fmt.Sprintf(format, args...)
+    recordMessage(msg)
+    return msg
+}
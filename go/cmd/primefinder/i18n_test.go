@@ -0,0 +1,88 @@
+// i18n_test.go
+package main
+
+import (
+    "regexp"
+    "slices"
+    "strings"
+    "testing"
+)
+
+// verbPattern matches fmt verbs, so translations can be checked to take the
+// same arguments in the same order
+var verbPattern = regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z%]`)
+
+func TestCatalogsMatchVerbs(t *testing.T) {
+    for lang, catalog := range catalogs {
+        for english, translated := range catalog {
+            want := verbPattern.FindAllString(english, -1)
+            got := verbPattern.FindAllString(translated, -1)
+            if !slices.Equal(got, want) {
+                t.Errorf("%s: %q has verbs %v, expected %v", lang, translated, got, want)
+            }
+        }
+    }
+}
+
+// foreignLetters holds letters that belong to one catalog language but
+// never appear in the other, catching text pasted into the wrong catalog
+var foreignLetters = map[string]string{
+    "de": "áéíóúñ¿¡",
+    "es": "äöüß",
+}
+
+func TestCatalogsSpotCheck(t *testing.T) {
+    for lang, letters := range foreignLetters {
+        for english, translated := range catalogs[lang] {
+            if strings.ContainsAny(translated, letters) {
+                t.Errorf("%s: translation of %q looks like another language: %q", lang, english, translated)
+            }
+        }
+    }
+    
+    tests := []struct {
+        lang, english, expected string
+    }{
+        {"de", "Admin endpoint listening on http://%s", "Admin-Endpunkt lauscht auf http://%s"},
+        {"es", "Admin endpoint listening on http://%s", "Punto de acceso de administración escuchando en http://%s"},
+        {"de", "Found %d primes in %v", "%d Primzahlen in %v gefunden"},
+        {"es", "Results saved to %s", "Resultados guardados en %s"},
+    }
+    for _, tt := range tests {
+        if got := catalogs[tt.lang][tt.english]; got != tt.expected {
+            t.Errorf("%s: %q translated as %q, expected %q", tt.lang, tt.english, got, tt.expected)
+        }
+    }
+}
+
+func TestSetLocale(t *testing.T) {
+    defer func(saved string) { locale = saved }(locale)
+    
+    tests := []struct {
+        name     string
+        expected string
+        wantErr  bool
+    }{
+        {"de", "de", false},
+        {"es_ES.UTF-8", "es", false},
+        {"DE-at", "de", false},
+        {"en", "", false},
+        {"C", "", false},
+        {"xx", "", true},
+    }
+    for _, tt := range tests {
+        locale = ""
+        err := setLocale(tt.name)
+        if (err != nil) != tt.wantErr || locale != tt.expected {
+            t.Errorf("setLocale(%q): locale %q, err %v; expected %q", tt.name, locale, err, tt.expected)
+        }
+    }
+    
+    locale = "de"
+    if got := tr("Results saved to %s", "out.json"); got != "Ergebnisse in out.json gespeichert" {
+        t.Errorf("tr gave %q", got)
+    }
+    if got := tr("untranslated %d", 1); got != "untranslated 1" {
+        t.Errorf("tr fallback gave %q", got)
+    }
+}
@@ -0,0 +1,120 @@
+// joblog.go
+package main
+
+import (
+    "encoding/json"
+    "fmt"
+    "net/http"
+    "slices"
+    "strconv"
+    "sync"
+    "time"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+// maxLogEvents bounds the events a run keeps for /jobs/{id}/logs; past it
+// the oldest half is dropped, and a reader that fell behind skips them
+const maxLogEvents = 10000
+
+// logEvent is one line of a run's event log
+type logEvent struct {
+    Time    time.Time                `json:"time"`
+    Event   string                   `json:"event"` // range, chunk, retry, warning, cancel or done
+    Range   *[2]int                  `json:"range,omitempty"`
+    Chunk   *primefinder.ChunkReport `json:"chunk,omitempty"`
+    Message string                   `json:"message,omitempty"`
+}
+
+// jobLog is the event log of one run. Readers wait on changed, which is
+// closed and replaced whenever an event is added; the log ends with a done
+// event.
+type jobLog struct {
+    mu      sync.Mutex
+    events  []logEvent
+    dropped int // events discarded from the front
+    changed chan struct{}
+    done    bool
+}
+
+func newJobLog() *jobLog {
+    return &jobLog{changed: make(chan struct{})}
+}
+
+// add appends an event, stamping it with the current time
+func (l *jobLog) add(e logEvent) {
+    l.mu.Lock()
+    defer l.mu.Unlock()
+    if l.done {
+        return
+    }
+    e.Time = time.Now()
+    l.events = append(l.events, e)
+    if len(l.events) > maxLogEvents {
+        n := len(l.events) / 2
+        l.events, l.dropped = slices.Clone(l.events[n:]), l.dropped+n
+    }
+    l.done = e.Event == "done"
+    close(l.changed)
+    l.changed = make(chan struct{})
+}
+
+// since returns the events from position pos on, the position after them, a
+// channel closed when the next event arrives, and whether the log has ended
+func (l *jobLog) since(pos int) ([]logEvent, int, <-chan struct{}, bool) {
+    l.mu.Lock()
+    defer l.mu.Unlock()
+    pos = max(pos, l.dropped)
+    events := slices.Clone(l.events[pos-l.dropped:])
+    return events, l.dropped + len(l.events), l.changed, l.done
+}
+
+// chunk logs a merged chunk, with a retry event if it needed more than one
+// attempt and a warning if it was not searched
+func (l *jobLog) chunk(c primefinder.ChunkReport) {
+    l.add(logEvent{Event: "chunk", Chunk: &c})
+    switch {
+    case c.Status == primefinder.ChunkQuarantined:
+        l.add(logEvent{Event: "warning", Chunk: &c, Message: fmt.Sprintf("chunk [%d, %d] quarantined after %d attempts", c.Start, c.End, c.Attempts)})
+    case c.Status == primefinder.ChunkLost:
+        l.add(logEvent{Event: "warning", Chunk: &c, Message: fmt.Sprintf("chunk [%d, %d] lost: its worker panicked", c.Start, c.End)})
+    case c.Attempts > 1:
+        l.add(logEvent{Event: "retry", Chunk: &c, Message: fmt.Sprintf("chunk [%d, %d] timed out %d times before finishing", c.Start, c.End, c.Attempts-1)})
+    }
+}
+
+// serve writes the log as NDJSON; with follow it keeps streaming events as
+// they arrive until the run ends or the client goes away
+func (l *jobLog) serve(w http.ResponseWriter, r *http.Request) {
+    follow := false
+    if raw := r.URL.Query().Get("follow"); raw != "" {
+        var err error
+        if follow, err = strconv.ParseBool(raw); err != nil {
+            http.Error(w, "follow must be 0 or 1", http.StatusBadRequest)
+            return
+        }
+    }
+    w.Header().Set("Content-Type", "application/x-ndjson")
+    encoder := json.NewEncoder(w)
+    flusher, _ := w.(http.Flusher)
+    for pos := 0; ; {
+        events, next, changed, done := l.since(pos)
+        for _, e := range events {
+            if encoder.Encode(e) != nil {
+                return
+            }
+        }
+        pos = next
+        if !follow || done {
+            return
+        }
+        if flusher != nil {
+            flusher.Flush()
+        }
+        select {
+        case <-changed:
+        case <-r.Context().Done():
+            return
+        }
+    }
+}
@@ -0,0 +1,139 @@
+// jsoncompat.go
+package main
+
+import (
+    "bytes"
+    "encoding/json"
+    "fmt"
+    "io"
+    "strings"
+)
+
+// maxSafeInteger is the largest integer JavaScript numbers represent exactly
+const maxSafeInteger = 1<<53 - 1
+
+// camelCase converts a snake_case JSON key to camelCase
+func camelCase(key string) string {
+    parts := strings.Split(key, "_")
+    for i := 1; i < len(parts); i++ {
+        if parts[i] != "" {
+            parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+        }
+    }
+    return strings.Join(parts, "")
+}
+
+// encodeJSCompat re-encodes JSON for JavaScript consumers: keys become
+// camelCase, every element of a "primes" array becomes a string, and any
+// other integer beyond 2^53 becomes a string so it survives JSON.parse.
+// Key order is preserved.
+func encodeJSCompat(data []byte) ([]byte, error) {
+    dec := json.NewDecoder(bytes.NewReader(data))
+    dec.UseNumber()
+    var out bytes.Buffer
+    if err := transcodeJS(dec, &out, false); err != nil {
+        return nil, err
+    }
+    return out.Bytes(), nil
+}
+
+// transcodeJS copies one JSON value from dec to out, applying the
+// encodeJSCompat rules; quoteNumbers forces numbers to strings
+func transcodeJS(dec *json.Decoder, out *bytes.Buffer, quoteNumbers bool) error {
+    tok, err := dec.Token()
+    if err != nil {
+        return err
+    }
+    
+    switch v := tok.(type) {
+    case json.Delim:
+        switch v {
+        case '{':
+            out.WriteByte('{')
+            for i := 0; dec.More(); i++ {
+                if i > 0 {
+                    out.WriteByte(',')
+                }
+                keyTok, err := dec.Token()
+                if err != nil {
+                    return err
+                }
+                key := keyTok.(string)
+                encoded, _ := json.Marshal(camelCase(key))
+                out.Write(encoded)
+                out.WriteByte(':')
+                if err := transcodeJS(dec, out, key == "primes"); err != nil {
+                    return err
+                }
+            }
+            _, err = dec.Token()
+            out.WriteByte('}')
+            return err
+        case '[':
+            out.WriteByte('[')
+            for i := 0; dec.More(); i++ {
+                if i > 0 {
+                    out.WriteByte(',')
+                }
+                if err := transcodeJS(dec, out, quoteNumbers); err != nil {
+                    return err
+                }
+            }
+            _, err = dec.Token()
+            out.WriteByte(']')
+            return err
+        }
+        return fmt.Errorf("unexpected delimiter %v", v)
+    case json.Number:
+        if quoteNumbers || !isSafeNumber(v) {
+            encoded, _ := json.Marshal(v.String())
+            out.Write(encoded)
+            return nil
+        }
+        out.WriteString(v.String())
+        return nil
+    default:
+        encoded, err := json.Marshal(v)
+        out.Write(encoded)
+        return err
+    }
+}
+
+// isSafeNumber reports whether a JSON number round-trips through a
+// JavaScript double
+func isSafeNumber(n json.Number) bool {
+    i, err := n.Int64()
+    if err != nil {
+        // Not an integer (or too large for int64); floats pass through
+        _, ferr := n.Float64()
+        return ferr == nil && strings.ContainsAny(n.String(), ".eE")
+    }
+    return i <= maxSafeInteger && i >= -maxSafeInteger
+}
+
+// writeJSON writes v as indented JSON, applying the named compatibility mode
+// ("" for plain JSON, "js" for JavaScript-safe output)
+func writeJSON(w io.Writer, v interface{}, compat string) error {
+    data, err := json.Marshal(v)
+    if err != nil {
+        return err
+    }
+    
+    switch compat {
+    case "":
+    case "js":
+        if data, err = encodeJSCompat(data); err != nil {
+            return err
+        }
+    default:
+        return fmt.Errorf("unknown JSON compatibility mode %q", compat)
+    }
+    
+    var indented bytes.Buffer
+    if err := json.Indent(&indented, data, "", "  "); err != nil {
+        return err
+    }
+    indented.WriteByte('\n')
+    _, err = w.Write(indented.Bytes())
+    return err
+}
@@ -0,0 +1,56 @@
+// jsoncompat_test.go
+package main
+
+import (
+    "bytes"
+    "strings"
+    "testing"
+)
+
+func TestCamelCase(t *testing.T) {
+    tests := map[string]string{
+        "start_range":            "startRange",
+        "execution_time_seconds": "executionTimeSeconds",
+        "workers":                "workers",
+    }
+    for in, expected := range tests {
+        if got := camelCase(in); got != expected {
+            t.Errorf("camelCase(%q) = %q, expected %q", in, got, expected)
+        }
+    }
+}
+
+func TestWriteJSONCompat(t *testing.T) {
+    result := Result{
+        StartRange:    9007199254740000,
+        EndRange:      9007199254740993,
+        PrimesFound:   2,
+        ExecutionTime: 0.5,
+        Workers:       4,
+        Primes:        []int{9007199254740881, 9007199254740997},
+    }
+    
+    var out bytes.Buffer
+    if err := writeJSON(&out, result, "js"); err != nil {
+        t.Fatalf("writeJSON failed: %v", err)
+    }
+    got := out.String()
+    
+    for _, want := range []string{
+        `"startRange": 9007199254740000`,
+        `"endRange": "9007199254740993"`,
+        `"executionTimeSeconds": 0.5`,
+        `"9007199254740881"`,
+    } {
+        if !strings.Contains(got, want) {
+            t.Errorf("Output missing %s:\n%s", want, got)
+        }
+    }
+    if strings.Index(got, "startRange") > strings.Index(got, "endRange") {
+        t.Error("Field order not preserved")
+    }
+    
+    if err := writeJSON(&out, result, "bogus"); err == nil {
+        t.Error("Expected error for unknown compatibility mode")
+    }
+}
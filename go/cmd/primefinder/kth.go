@@ -0,0 +1,83 @@
+// kth.go
+package main
+
+import (
+    "flag"
+    "fmt"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+// kthPrimeAfter returns the k-th prime strictly greater than n
+func kthPrimeAfter(n, k int) int {
+    if n < 1 {
+        n = 1
+    }
+    for candidate := n + 1; ; candidate++ {
+        if primefinder.IsProbablePrime(candidate) {
+            k--
+            if k == 0 {
+                return candidate
+            }
+        }
+    }
+}
+
+// kthPrimeBefore returns the k-th prime strictly less than n; ok is false
+// when fewer than k primes lie below n
+func kthPrimeBefore(n, k int) (prime int, ok bool) {
+    for candidate := n - 1; candidate >= 2; candidate-- {
+        if primefinder.IsProbablePrime(candidate) {
+            k--
+            if k == 0 {
+                return candidate, true
+            }
+        }
+    }
+    return 0, false
+}
+
+// parseKthArgs parses the shared `N k` arguments of kthafter and kthbefore
+func parseKthArgs(name string, args []string) (n, k int, err error) {
+    fs := flag.NewFlagSet(name, flag.ExitOnError)
+    if err := parseFlags(fs, args); err != nil {
+        return 0, 0, err
+    }
+    if fs.NArg() != 2 {
+        return 0, 0, fmt.Errorf("%w: usage: %s N k", primefinder.ErrInvalidArgument, name)
+    }
+    if n, err = parseNumber(fs.Arg(0)); err != nil {
+        return 0, 0, err
+    }
+    if k, err = parseNumber(fs.Arg(1)); err != nil {
+        return 0, 0, err
+    }
+    if k < 1 {
+        return 0, 0, fmt.Errorf("%w: k must be at least 1", primefinder.ErrInvalidArgument)
+    }
+    return n, k, nil
+}
+
+// runKthAfter implements `kthafter N k`
+func runKthAfter(args []string) error {
+    n, k, err := parseKthArgs("kthafter", args)
+    if err != nil {
+        return err
+    }
+    fmt.Println(kthPrimeAfter(n, k))
+    return nil
+}
+
+// runKthBefore implements `kthbefore N k`
+func runKthBefore(args []string) error {
+    n, k, err := parseKthArgs("kthbefore", args)
+    if err != nil {
+        return err
+    }
+    prime, ok := kthPrimeBefore(n, k)
+    if !ok {
+        return fmt.Errorf("%w: fewer than %d primes below %d", primefinder.ErrInvalidArgument, k, n)
+    }
+    fmt.Println(prime)
+    return nil
+}
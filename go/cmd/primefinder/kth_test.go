@@ -0,0 +1,34 @@
+// kth_test.go
+package main
+
+import (
+    "errors"
+    "testing"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+func TestKthPrime(t *testing.T) {
+    if got := kthPrimeAfter(10, 1); got != 11 {
+        t.Errorf("1st prime after 10 = %d, expected 11", got)
+    }
+    if got := kthPrimeAfter(11, 3); got != 19 {
+        t.Errorf("3rd prime after 11 = %d, expected 19", got)
+    }
+    if got := kthPrimeAfter(0, 1); got != 2 {
+        t.Errorf("1st prime after 0 = %d, expected 2", got)
+    }
+    if got, ok := kthPrimeBefore(20, 2); !ok || got != 17 {
+        t.Errorf("2nd prime before 20 = %d, %v; expected 17", got, ok)
+    }
+    if _, ok := kthPrimeBefore(10, 5); ok {
+        t.Error("Expected no 5th prime before 10")
+    }
+}
+
+func TestKthBeforeTooFew(t *testing.T) {
+    // Only 2 and 3 lie below 5, so asking for the 3rd is an argument error
+    if err := runKthBefore([]string{"5", "3"}); !errors.Is(err, primefinder.ErrInvalidArgument) {
+        t.Errorf("kthbefore 5 3: got %v, expected ErrInvalidArgument", err)
+    }
+}
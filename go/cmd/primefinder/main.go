@@ -0,0 +1,890 @@
+// main.go
+package main
+
+import (
+    "bytes"
+    "context"
+    "flag"
+    "fmt"
+    "os"
+    "os/signal"
+    "runtime"
+    "runtime/trace"
+    "slices"
+    "strings"
+    "syscall"
+    "time"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+type Result struct {
+    RunID             string                       `json:"run_id"`
+    StartRange        int                          `json:"start_range"`
+    EndRange          int                          `json:"end_range"`
+    PrimesFound       int                          `json:"primes_found"`
+    ExecutionTime     float64                      `json:"execution_time_seconds"`
+    DurationNS        int64                        `json:"duration_ns"`
+    Duration          string                       `json:"duration"`
+    Timings           *Timings                     `json:"timings,omitempty"`
+    Workers           int                          `json:"workers"`
+    Executor          string                       `json:"executor,omitempty"`
+    Algorithm         string                       `json:"algorithm"`
+    Verdict           primefinder.Verdict          `json:"verdict"`
+    Wheel             int                          `json:"wheel,omitempty"`
+    Deterministic     bool                         `json:"deterministic,omitempty"`
+    Seed              uint64                       `json:"seed,omitempty"`
+    Transforms        string                       `json:"transforms,omitempty"`
+    Pairs             *PairReport                  `json:"pairs,omitempty"`
+    Gaps              *primefinder.GapReport       `json:"gaps,omitempty"`
+    PrimesEmitted     int                          `json:"primes_emitted,omitempty"`
+    QuarantinedChunks [][2]int                     `json:"quarantined_chunks,omitempty"`
+    KnownValueCheck   *primefinder.KnownValueCheck `json:"known_value_check,omitempty"`
+    Features          []string                     `json:"features,omitempty"`
+    Unordered         bool                         `json:"unordered,omitempty"`
+    Descending        bool                         `json:"descending,omitempty"`
+    Stride            int                          `json:"stride,omitempty"`
+    Offset            int                          `json:"offset,omitempty"`
+    Cancelled         bool                         `json:"cancelled,omitempty"`
+    UnsearchedChunks  [][2]int                     `json:"unsearched_chunks,omitempty"`
+    ResumedFrom       int                          `json:"resumed_from,omitempty"`
+    DeadlineReached   bool                         `json:"deadline_reached,omitempty"`
+    CompletePrefix    *[2]int                      `json:"complete_prefix,omitempty"`
+    CountOnly         bool                         `json:"count_only,omitempty"`
+    Limit             int                          `json:"limit,omitempty"`
+    LimitReached      bool                         `json:"limit_reached,omitempty"`
+    WorkerUtilization []WorkerUtilization          `json:"worker_utilization,omitempty"`
+    WorkersAuto       bool                         `json:"workers_auto,omitempty"`
+    WorkerCounts      []WorkerCount                `json:"worker_count_over_time,omitempty"`
+    Primes            []int                        `json:"primes,omitempty"`
+    Ranges            []RangeResult                `json:"ranges,omitempty"`
+    Summary           *RangeSummary                `json:"summary,omitempty"`
+}
+
+// maxCacheLimit keeps the -cache-limit sieve to about a GB
+const maxCacheLimit = 1_000_000_000
+
+// maxTrialEstimate is the longest trial division search run without
+// -force-algorithm
+const maxTrialEstimate = time.Hour
+
+// checkTrialDivision refuses trial division of ranges estimated to take
+// longer than maxTrialEstimate, pointing at the algorithms that would not
+func checkTrialDivision(ranges [][2]int, stride, workers, wheel int) error {
+    var total time.Duration
+    var worst primefinder.TrialEstimate
+    for _, r := range ranges {
+        estimate := primefinder.EstimateTrialDivision(r[0], r[1], stride, workers, wheel)
+        total += estimate.Total
+        worst.PerPrime = max(worst.PerPrime, estimate.PerPrime)
+    }
+    if total <= maxTrialEstimate {
+        return nil
+    }
+    return fmt.Errorf("%w: trial division would take about %v with %d workers (%v for each prime at the top of the range); "+
+        "use -algorithm sieve for whole ranges or -algorithm miller-rabin for sparse candidates, or -force-algorithm to run it anyway",
+        primefinder.ErrInvalidArgument, total.Round(time.Minute), workers, worst.PerPrime.Round(time.Millisecond))
+}
+
+// executors lists the worker execution models selectable with -executor
+var executors = map[string]bool{
+    "goroutine": false, // workers are ordinary goroutines
+    "thread":    true,  // each worker locks to an OS thread
+}
+
+// findPrimesSequential finds primes sequentially for comparison
+func findPrimesSequential(start, end int, cfg primefinder.Config) ([]int, time.Duration, error) {
+    startTime := time.Now()
+    primes, err := primefinder.FindRangeConfig(start, end, cfg)
+    return primes, time.Since(startTime), err
+}
+
+// countPrimesSequential counts primes sequentially without storing them
+func countPrimesSequential(start, end int, cfg primefinder.Config) (int, time.Duration, error) {
+    startTime := time.Now()
+    count, err := primefinder.CountRangeConfig(start, end, cfg)
+    return count, time.Since(startTime), err
+}
+
+// subcommands maps subcommand names to their entry points; each receives the
+// arguments that follow the subcommand name
+var subcommands = map[string]func(args []string) error{
+    "analyze":   runAnalyze,
+    "annotate":  runAnnotate,
+    "bench":     runBench,
+    "bugreport": runBugReport,
+    "chains":    runChains,
+    "count":     runCount,
+    "delta":     runDelta,
+    "factor":    runFactor,
+    "genfermat": runGenFermat,
+    "goldbach":  runGoldbach,
+    "kthafter":  runKthAfter,
+    "kthbefore": runKthBefore,
+    "nthprime":  runNthPrime,
+    "oeis":      runOEIS,
+    "proth":     runProth,
+    "randprime": runRandPrime,
+    "rare":      runRare,
+    "selftest":  runSelfTest,
+    "serve":     runServe,
+    "simulate":  runSimulate,
+}
+
+func main() {
+    defer crashHandler()
+    
+    var err error
+    if cmd, ok := subcommands[firstArg(os.Args)]; ok {
+        err = cmd(os.Args[2:])
+    } else {
+        err = runFind(os.Args[1:])
+    }
+    if err != nil {
+        exitWithError(err)
+    }
+}
+
+// firstArg returns the argument after the program name, if any
+func firstArg(args []string) string {
+    if len(args) < 2 {
+        return ""
+    }
+    return args[1]
+}
+
+// runFind implements the default prime search
+func runFind(args []string) error {
+    workerCount := workersFlag{n: runtime.NumCPU()}
+    var (
+        start              = flag.Int("start", 1, "Start of range")
+        end                = flag.Int("end", 100000, "End of range")
+        bigStart           = flag.String("big-start", "", "Arbitrary precision start, e.g. 2^64 (with -big-end; uses probable-prime tests)")
+        bigEnd             = flag.String("big-end", "", "Arbitrary precision end, e.g. 2^64+1000000")
+        mersenne           = flag.Bool("mersenne", false, "Search for Mersenne primes 2^p-1 with the Lucas-Lehmer test, for prime p up to -max-exponent")
+        maxExponent        = flag.Int("max-exponent", 5000, "Largest exponent p tested by -mersenne")
+        rangeSpec          = flag.String("ranges", "", "Comma-separated START..END ranges searched in one run, reported per range (replaces -start/-end)")
+        workers            = &workerCount.n
+        sequential         = flag.Bool("sequential", false, "Run sequential version")
+        algorithmName      = flag.String("algorithm", primefinder.AlgorithmAuto, "Search algorithm: trial, sieve (segmented), miller-rabin, or auto (sieve from end >= 1e7)")
+        forceAlgorithm     = flag.Bool("force-algorithm", false, fmt.Sprintf("Run trial division even when it is estimated to take over %v", maxTrialEstimate))
+        wheel              = flag.Int("wheel", 0, "Factorization wheel for trial division and the sieve: 30 or 210 skip more composites than the default 2-3 wheel")
+        mrRounds           = flag.Int("mr-rounds", 0, "Miller-Rabin rounds with random bases (0: deterministic witnesses, exact for 64-bit)")
+        savePrimes         = flag.Bool("save-primes", false, "Save actual prime numbers")
+        countOnly          = flag.Bool("count-only", false, "Only count primes: workers report a count per chunk and no primes are held in memory")
+        scheduler          = flag.String("scheduler", primefinder.SchedulerDynamic, "Chunk scheduler: dynamic (shrinking chunks handed out on demand) or static (one equal chunk per worker)")
+        limit              = flag.Int("limit", 0, "Stop once this many primes are found (per range with -ranges), keeping the lowest; 0 for no limit")
+        stride             = flag.Int("stride", 0, "Only test numbers congruent to -offset modulo this, e.g. -stride 4 -offset 3 for primes 4n+3 (0: all numbers)")
+        offset             = flag.Int("offset", 0, "Residue of the numbers tested with -stride")
+        descending         = flag.Bool("descending", false, "Search from the end of the range down and list primes in descending order")
+        deterministic      = flag.Bool("deterministic", false, "Make identical scheduling decisions on every run: chunk i always goes to worker i mod workers, chunks merge in range order, and random bases are seeded from -seed")
+        seed               = flag.Uint64("seed", 1, "Seed for the random Miller-Rabin bases with -deterministic")
+        unordered          = flag.Bool("unordered", false, "Keep chunk results in completion order instead of ascending order, for maximum throughput")
+        stream             = flag.Bool("stream", false, "Write primes to stdout, one per line, as they are found instead of saving a results file")
+        output             = flag.String("output", "results.json", "Output file ({run_id} is replaced by the run ID; the extension follows -format unless given)")
+        format             = flag.String("format", "json", "Output format: json (full result), csv (one prime per row), ndjson (one JSON object per prime) or bin (delta-encoded varints); all but json imply -save-primes")
+        softDeadline       = flag.Duration("soft-deadline", 0, "Stop the search after this long, keeping the searched part a contiguous prefix of the range, which is reported (0 disables)")
+        chunkTimeout       = flag.Duration("chunk-timeout", 0, "Per-chunk time limit before a retry (0 disables)")
+        chunkRetries       = flag.Int("chunk-retries", 2, "Retries for a timed-out chunk before it is quarantined")
+        jsonCompat         = flag.String("json-compat", "", "JSON compatibility mode: js (camelCase keys, large numbers as strings)")
+        gogc               = flag.String("gogc", "", "GC target percentage, or off (default: runtime/GOGC setting)")
+        memLimit           = flag.String("memory-limit", "", "Soft memory limit, e.g. 4GiB (default: runtime/GOMEMLIMIT setting)")
+        ballast            = flag.String("ballast", "", "Size of a heap ballast allocation, e.g. 256MiB")
+        profileDir         = flag.String("profile-dir", "", "Write CPU and heap profiles for the run to this directory, named by run ID")
+        traceFile          = flag.String("trace", "", "Write a runtime execution trace of the search to this file (view with go tool trace)")
+        checkpointPath     = flag.String("checkpoint", "", "Save search progress to this file periodically so -resume can continue after a crash or cancellation")
+        checkpointInterval = flag.Duration("checkpoint-interval", 30*time.Second, "Time between checkpoint saves")
+        resume             = flag.Bool("resume", false, "Continue the search saved in the -checkpoint file (its range and -stride/-offset replace those given)")
+        recordCosts        = flag.String("record-costs", "", "Write per-chunk compute times as CSV for the simulate subcommand")
+        executor           = flag.String("executor", "goroutine", "Worker execution model: goroutine, or thread (one locked OS thread per worker, GOMAXPROCS = workers)")
+        verbose            = flag.Bool("verbose", false, "Print GC statistics and the time and share of wall time of each phase for the run")
+        featureList        = flag.String("features", "", "Comma-separated experimental features to switch on (also read from "+featuresEnv+"): "+strings.Join(experimentNames(), ", "))
+        adminAddr          = flag.String("admin-addr", "", "Serve /status (progress JSON), /cancel (POST) and /debug/pprof/ on this address during the run, e.g. :6061")
+        progress           = flag.String("progress", progressOff, "Progress on stderr: off, tty (redrawn line), plain (line per update, for screen readers and logs), or auto (plain unless stderr is a terminal)")
+        lang               = flag.String("lang", "", langUsage)
+        pairKind           = flag.String("pairs", "", "Find prime pairs (p, p+gap): twin (gap 2), cousin (4) or sexy (6); counted in the result, listed with -save-primes")
+        gaps               = flag.Bool("gaps", false, "Report gaps between consecutive primes: the largest and where it is, the average, and a histogram")
+        gapBucket          = flag.Int("gap-bucket", 2, "Width of the -gaps histogram buckets")
+        timeUnit           = flag.String("time-unit", "ms", "Unit of the phase timings in the result: ns, us, ms or s")
+        transforms         = flag.String("transform", "", "Comma-separated transforms applied to the primes of each range as its chunks are merged (dedupe, sample:N, residue:M:R with 0 <= R < M, pairs:G keeping p when p+G is prime, as -pairs counts)")
+    )
+    flag.Var(&workerCount, "workers", "Number of workers, or auto to start with one per CPU and add or remove workers by measured throughput and queue backlog")
+    cacheLimit := numberFlag(primefinder.DefaultCacheLimit)
+    flag.Var(&cacheLimit, "cache-limit", "Cache the primes up to this bound once, for trial division and sieving (trial division by primes alone covers numbers up to its square)")
+    
+    flag.CommandLine.Parse(args)
+    
+    if err := applyLang(*lang); err != nil {
+        return err
+    }
+    
+    if *adminAddr != "" && (*sequential || *stream || *bigStart != "") {
+        return fmt.Errorf("%w: -admin-addr cannot be combined with -sequential, -stream or -big-start", primefinder.ErrInvalidArgument)
+    }
+    if *format != "json" && (*bigStart != "" || *stream || *resume) {
+        return fmt.Errorf("%w: -format=%s cannot be combined with -big-start, -stream or -resume", primefinder.ErrInvalidArgument, *format)
+    }
+    if *mersenne {
+        if *bigStart != "" || *bigEnd != "" || *rangeSpec != "" || *stream || *sequential || *adminAddr != "" || *format != "json" {
+            return fmt.Errorf("%w: -mersenne cannot be combined with -big-start, -ranges, -stream, -sequential, -admin-addr or -format", primefinder.ErrInvalidArgument)
+        }
+        return runMersenne(*maxExponent, *workers, *output)
+    }
+    if *bigStart != "" || *bigEnd != "" {
+        if *bigStart == "" || *bigEnd == "" {
+            return fmt.Errorf("%w: -big-start and -big-end must be given together", primefinder.ErrInvalidArgument)
+        }
+        if *jsonCompat != "" && *jsonCompat != "js" {
+            return fmt.Errorf("%w: unknown -json-compat mode %q", primefinder.ErrInvalidArgument, *jsonCompat)
+        }
+        return runFindBig(bigSearch{
+            start:      *bigStart,
+            end:        *bigEnd,
+            workers:    *workers,
+            savePrimes: *savePrimes,
+            output:     *output,
+            jsonCompat: *jsonCompat,
+        })
+    }
+    
+    if *limit < 0 {
+        return fmt.Errorf("%w: -limit must not be negative, got %d", primefinder.ErrInvalidArgument, *limit)
+    }
+    if *limit > 0 && (*unordered || *resume) {
+        return fmt.Errorf("%w: -limit cannot be combined with -unordered or -resume", primefinder.ErrInvalidArgument)
+    }
+    if *countOnly && (*savePrimes || *transforms != "" || *limit > 0 || *stream || *format != "json") {
+        return fmt.Errorf("%w: -count-only cannot be combined with -save-primes, -transform, -limit, -stream or -format", primefinder.ErrInvalidArgument)
+    }
+    if _, ok := timeUnits[*timeUnit]; !ok {
+        return fmt.Errorf("%w: unknown -time-unit %q (ns, us, ms or s)", primefinder.ErrInvalidArgument, *timeUnit)
+    }
+    if *pairKind != "" {
+        if _, err := primefinder.PairGap(*pairKind); err != nil {
+            return err
+        }
+    }
+    if *gaps {
+        if _, err := primefinder.NewGapStats(*gapBucket); err != nil {
+            return err
+        }
+        if *stream {
+            return fmt.Errorf("%w: -gaps cannot be combined with -stream", primefinder.ErrInvalidArgument)
+        }
+    }
+    if (*pairKind != "" || *gaps) && (*countOnly || *stride > 1 || *descending || *resume || *unordered) {
+        return fmt.Errorf("%w: -pairs and -gaps cannot be combined with -count-only, -stride, -descending, -resume or -unordered, which leave them without every prime in ascending order", primefinder.ErrInvalidArgument)
+    }
+    if *descending && (*checkpointPath != "" || *transforms != "") {
+        return fmt.Errorf("%w: -descending cannot be combined with -checkpoint or -transform", primefinder.ErrInvalidArgument)
+    }
+    if *unordered && *transforms != "" {
+        return fmt.Errorf("%w: -transform cannot be combined with -unordered; transforms take the primes in ascending order", primefinder.ErrInvalidArgument)
+    }
+    if *deterministic && (*unordered || *softDeadline != 0 || *chunkTimeout != 0) {
+        return fmt.Errorf("%w: -deterministic cannot be combined with -unordered, -soft-deadline or -chunk-timeout, whose decisions depend on timing", primefinder.ErrInvalidArgument)
+    }
+    if *softDeadline != 0 && (*sequential || *stream) {
+        return fmt.Errorf("%w: -soft-deadline cannot be combined with -sequential or -stream", primefinder.ErrInvalidArgument)
+    }
+    
+    var resumed *Checkpoint
+    if *checkpointPath != "" {
+        if *rangeSpec != "" || *sequential || *stream {
+            return fmt.Errorf("%w: -checkpoint cannot be combined with -ranges, -sequential or -stream", primefinder.ErrInvalidArgument)
+        }
+        if *resume {
+            if *savePrimes || *transforms != "" {
+                return fmt.Errorf("%w: -resume cannot be combined with -save-primes or -transform, which need the primes found before the checkpoint", primefinder.ErrInvalidArgument)
+            }
+            c, err := loadCheckpoint(*checkpointPath)
+            if err != nil {
+                return err
+            }
+            resumed = &c
+            *start, *end = c.StartRange, c.EndRange
+            *stride, *offset = c.Stride, c.Offset
+            if *algorithmName == primefinder.AlgorithmAuto && c.Algorithm != "" {
+                *algorithmName = c.Algorithm
+            }
+        }
+    } else if *resume {
+        return fmt.Errorf("%w: -resume needs -checkpoint", primefinder.ErrInvalidArgument)
+    }
+    
+    ranges := [][2]int{{*start, *end}}
+    if *rangeSpec != "" {
+        var err error
+        if ranges, err = parseRanges(*rangeSpec); err != nil {
+            return fmt.Errorf("-ranges: %w", err)
+        }
+    }
+    maxEnd := ranges[0][1]
+    for _, r := range ranges {
+        if err := primefinder.ValidateRange(r[0], r[1], *workers); err != nil {
+            return err
+        }
+        maxEnd = max(maxEnd, r[1])
+    }
+    if *jsonCompat != "" && *jsonCompat != "js" {
+        return fmt.Errorf("%w: unknown -json-compat mode %q", primefinder.ErrInvalidArgument, *jsonCompat)
+    }
+    writer, err := newOutputWriter(*format, *jsonCompat)
+    if err != nil {
+        return err
+    }
+    if *format != "json" {
+        *savePrimes = true
+        if !flagSet(flag.CommandLine, "output") {
+            *output = "results." + outputFormats[*format]
+        }
+    }
+    if err := primefinder.ValidateStride(*stride, *offset); err != nil {
+        return err
+    }
+    if err := primefinder.ValidateWheel(*wheel); err != nil {
+        return err
+    }
+    
+    algorithm, err := primefinder.ResolveAlgorithm(*algorithmName, maxEnd)
+    if err != nil {
+        return err
+    }
+    if cacheLimit < 0 || cacheLimit > maxCacheLimit {
+        return fmt.Errorf("%w: -cache-limit must be in [0, %d], got %d", primefinder.ErrInvalidArgument, maxCacheLimit, cacheLimit)
+    }
+    primefinder.WarmCache(int(cacheLimit))
+    if algorithm == primefinder.AlgorithmTrial && !*forceAlgorithm {
+        trialWorkers := *workers
+        if *sequential {
+            trialWorkers = 1
+        }
+        if err := checkTrialDivision(ranges, *stride, trialWorkers, *wheel); err != nil {
+            return err
+        }
+    }
+    
+    progressMode, err := resolveProgressMode(*progress, os.Stderr)
+    if err != nil {
+        return err
+    }
+    
+    features, err := loadFeatures(*featureList)
+    if err != nil {
+        return err
+    }
+    
+    lockThreads, ok := executors[*executor]
+    if !ok {
+        return fmt.Errorf("%w: unknown -executor %q", primefinder.ErrInvalidArgument, *executor)
+    }
+    if lockThreads {
+        if err := features.require("thread-executor"); err != nil {
+            return err
+        }
+        runtime.GOMAXPROCS(*workers)
+    }
+    
+    if _, err := primefinder.ParseTransforms(*transforms); err != nil {
+        return fmt.Errorf("%w: %v", primefinder.ErrInvalidArgument, err)
+    }
+    
+    if *stream {
+        if *rangeSpec != "" || *sequential || (*transforms != "" && *pairKind != "") {
+            return fmt.Errorf("%w: -stream cannot be combined with -ranges, -sequential or both -pairs and -transform", primefinder.ErrInvalidArgument)
+        }
+        pipeline, _ := primefinder.ParseTransforms(*transforms)
+        return runStream(*start, *end, *workers, primefinder.Config{
+            ChunkTimeout:  *chunkTimeout,
+            ChunkRetries:  *chunkRetries,
+            LockThreads:   lockThreads,
+            Algorithm:     algorithm,
+            MRRounds:      *mrRounds,
+            Wheel:         *wheel,
+            Deterministic: *deterministic,
+            Seed:          *seed,
+            Unordered:     *unordered,
+            Scheduler:     *scheduler,
+            Limit:         *limit,
+            Descending:    *descending,
+            Stride:        *stride,
+            Offset:        *offset,
+            Autoscale:     workerCount.auto,
+            Pipeline:      pipeline,
+        }, *pairKind)
+    }
+    
+    ballastBuf, err := gcSettings{gogc: *gogc, memoryLimit: *memLimit, ballast: *ballast}.apply()
+    if err != nil {
+        return err
+    }
+    defer runtime.KeepAlive(ballastBuf)
+    
+    runID := newRunID()
+    *output = expandRunID(*output, runID)
+    
+    if *rangeSpec == "" {
+        fmt.Println(tr("Run %s: finding primes from %d to %d", runID, *start, *end))
+    } else {
+        fmt.Println(tr("Run %s: finding primes in %d ranges", runID, len(ranges)))
+    }
+    
+    // Per-range search output, kept until profiling and tracing stop
+    type rangeSearch struct {
+        primes        []int
+        count         int
+        duration      time.Duration
+        quarantined   [][2]int
+        unsearched    [][2]int
+        workers       []primefinder.WorkerStats
+        timeline      []primefinder.WorkerSample
+        deadline      bool
+        limited       bool
+        covered       int
+        coveredPrimes int
+        pairs         *PairReport
+        gaps          *primefinder.GapStats
+    
+        // Found by the runs before a resumed checkpoint
+        priorPrimes   int
+        priorDuration time.Duration
+    }
+    searches := make([]rangeSearch, len(ranges))
+    var phases runPhases
+    cancelled := false
+    var chunkCosts []primefinder.ChunkCost
+    gcBefore := takeGCSnapshot()
+    
+    var stopProfiles func() error
+    if *profileDir != "" {
+        if stopProfiles, err = startProfiles(*profileDir, runID); err != nil {
+            return err
+        }
+        // Keep the profiles of a run that fails; a no-op once stopped below
+        defer stopProfiles()
+    }
+    if *traceFile != "" {
+        file, err := os.Create(*traceFile)
+        if err != nil {
+            return fmt.Errorf("%w: %v", primefinder.ErrSinkWrite, err)
+        }
+        defer file.Close()
+        if err := trace.Start(file); err != nil {
+            return err
+        }
+        // Flush the trace before the file closes if the run fails
+        defer trace.Stop()
+    }
+    
+    var ckpt *checkpointer
+    if *checkpointPath != "" {
+        base := Checkpoint{StartRange: *start, EndRange: *end, Algorithm: algorithm, Stride: *stride, Offset: *offset, Covered: *start - 1}
+        if resumed != nil {
+            base = *resumed
+            base.Algorithm = algorithm
+            searches[0].priorPrimes = resumed.PrimesFound
+            searches[0].priorDuration = time.Duration(resumed.ElapsedSeconds * float64(time.Second))
+            fmt.Println(tr("Resuming from %s: searched up to %d, %d primes found", *checkpointPath, resumed.Covered, resumed.PrimesFound))
+        }
+        ckpt = newCheckpointer(*checkpointPath, *checkpointInterval, base)
+    }
+    
+    if *sequential {
+        fmt.Println(tr("Running sequential version (%s)...", algorithm))
+    } else {
+        if workerCount.auto {
+            fmt.Println(tr("Running concurrent version with %d workers, autoscaled between 1 and %d (%s executor, %s)...", *workers, 2**workers, *executor, algorithm))
+        } else {
+            fmt.Println(tr("Running concurrent version with %d workers (%s executor, %s)...", *workers, *executor, algorithm))
+        }
+    }
+    
+    // SIGINT or SIGTERM cancels a concurrent search; the partial result is
+    // still written. Once cancelled, a second signal terminates as usual.
+    // -admin-addr serves the run's status and a /cancel that acts like a
+    // signal
+    runCtx, cancelRun := context.WithCancel(context.Background())
+    defer cancelRun()
+    var admin *adminServer
+    if *adminAddr != "" {
+        admin = newAdminServer(runID, len(ranges), cancelRun)
+        addr, err := admin.start(*adminAddr)
+        if err != nil {
+            return fmt.Errorf("%w: -admin-addr: %v", primefinder.ErrInvalidArgument, err)
+        }
+        defer admin.close()
+        fmt.Println(tr("Admin endpoint listening on http://%s", addr))
+    }
+    ctx, stopSignals := runCtx, func() {}
+    if !*sequential {
+        ctx, stopSignals = signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+        go func() {
+            <-ctx.Done()
+            stopSignals()
+        }()
+    }
+    var deadline time.Time
+    if *softDeadline > 0 {
+        deadline = time.Now().Add(*softDeadline)
+    }
+    deadlineReached := false
+    for i, r := range ranges {
+        // Transforms keep state, so each range gets a fresh pipeline, which
+        // -pairs and -gaps watch ahead of the -transform stages
+        var pipeline primefinder.Pipeline
+        if *pairKind != "" {
+            var stage primefinder.Transform
+            searches[i].pairs, stage = pairStage(*pairKind, *savePrimes)
+            pipeline = append(pipeline, stage)
+        }
+        if *gaps {
+            searches[i].gaps, _ = primefinder.NewGapStats(*gapBucket)
+            pipeline = append(pipeline, searches[i].gaps)
+        }
+        stages, _ := primefinder.ParseTransforms(*transforms)
+        pipeline = append(pipeline, stages...)
+        if cancelled || deadlineReached {
+            searches[i].deadline = deadlineReached
+            searches[i].covered = r[0] - 1
+            searches[i].unsearched = [][2]int{r}
+            continue
+        }
+        if *sequential {
+            cfg := primefinder.Config{
+                Algorithm:     algorithm,
+                MRRounds:      *mrRounds,
+                Wheel:         *wheel,
+                Deterministic: *deterministic,
+                Seed:          *seed,
+                Stride:        *stride,
+                Offset:        *offset,
+            }
+            if *countOnly {
+                searches[i].count, searches[i].duration, err = countPrimesSequential(r[0], r[1], cfg)
+                if err != nil {
+                    return err
+                }
+                phases.Compute += searches[i].duration
+                continue
+            }
+            searches[i].primes, searches[i].duration, err = findPrimesSequential(r[0], r[1], cfg)
+            if err != nil {
+                return err
+            }
+            phases.Compute += searches[i].duration
+            if *descending {
+                slices.Reverse(searches[i].primes)
+            }
+            if *limit > 0 && len(searches[i].primes) >= *limit {
+                searches[i].primes, searches[i].limited = searches[i].primes[:*limit], true
+                searches[i].covered = searches[i].primes[*limit-1]
+            }
+            searches[i].count = len(searches[i].primes)
+            if len(pipeline) > 0 {
+                searches[i].primes = pipeline.Run(searches[i].primes)
+            }
+            continue
+        }
+        var onProgress func(primefinder.Progress)
+        var reporter *progressReporter
+        if progressMode != progressOff {
+            reporter = &progressReporter{w: os.Stderr, mode: progressMode}
+            if *rangeSpec != "" {
+                reporter.label = fmt.Sprintf("[%d, %d] ", r[0], r[1])
+            }
+            reporter.start()
+            onProgress = reporter.update
+        }
+        lo, maxChunk := r[0], 0
+        if ckpt != nil {
+            maxChunk = checkpointChunk
+            lo, _ = ckpt.base.remaining()
+            report := onProgress
+            onProgress = func(p primefinder.Progress) {
+                ckpt.update(p)
+                if report != nil {
+                    report(p)
+                }
+            }
+        }
+        if admin != nil {
+            admin.startRange(i, r)
+            report := onProgress
+            onProgress = func(p primefinder.Progress) {
+                admin.update(p)
+                if report != nil {
+                    report(p)
+                }
+            }
+        }
+        search := primefinder.FindRangeConcurrentContext(ctx, lo, r[1], *workers, primefinder.Config{
+            ChunkTimeout:  *chunkTimeout,
+            ChunkRetries:  *chunkRetries,
+            LockThreads:   lockThreads,
+            Algorithm:     algorithm,
+            MRRounds:      *mrRounds,
+            Wheel:         *wheel,
+            Deterministic: *deterministic,
+            Seed:          *seed,
+            OnProgress:    onProgress,
+            Unordered:     *unordered,
+            Scheduler:     *scheduler,
+            MaxChunk:      maxChunk,
+            SoftDeadline:  deadline,
+            Limit:         *limit,
+            Descending:    *descending,
+            Stride:        *stride,
+            Offset:        *offset,
+            CountOnly:     *countOnly,
+            Autoscale:     workerCount.auto,
+            Pipeline:      pipeline,
+        })
+        if reporter != nil {
+            reporter.stop()
+        }
+        if search.Err != nil {
+            return search.Err
+        }
+        searches[i].primes, searches[i].count, searches[i].duration = search.Primes, search.Count, search.Duration
+        phases.add(search.Phases)
+        searches[i].quarantined, searches[i].unsearched = search.Quarantined, search.Unsearched
+        searches[i].workers, searches[i].timeline = search.Workers, search.WorkerTimeline
+        searches[i].deadline = search.DeadlineReached
+        searches[i].limited = search.LimitReached
+        searches[i].covered, searches[i].coveredPrimes = search.Covered, search.CoveredPrimes
+        if search.DeadlineReached {
+            deadlineReached = true
+            if progressMode == progressTTY {
+                fmt.Fprintln(os.Stderr)
+            }
+        }
+        chunkCosts = append(chunkCosts, search.ChunkCosts...)
+        if search.Cancelled {
+            cancelled = true
+            if progressMode == progressTTY {
+                fmt.Fprintln(os.Stderr)
+            }
+            fmt.Println(tr("Search cancelled; writing partial results"))
+        }
+    }
+    stopSignals()
+    
+    if ckpt != nil {
+        if err := ckpt.flush(); err != nil {
+            return fmt.Errorf("%s: %w", *checkpointPath, err)
+        }
+        fmt.Println(tr("Checkpoint saved to %s: searched up to %d", *checkpointPath, ckpt.latest.Covered))
+    }
+    
+    if *recordCosts != "" && !*sequential {
+        file, err := os.Create(*recordCosts)
+        if err != nil {
+            return fmt.Errorf("%w: %v", primefinder.ErrSinkWrite, err)
+        }
+        err = writeChunkCosts(file, chunkCosts)
+        file.Close()
+        if err != nil {
+            return fmt.Errorf("%w: %s: %v", primefinder.ErrSinkWrite, *recordCosts, err)
+        }
+    }
+    
+    if *traceFile != "" {
+        trace.Stop()
+        fmt.Println(tr("Execution trace written to %s", *traceFile))
+    }
+    if stopProfiles != nil {
+        if err := stopProfiles(); err != nil {
+            return err
+        }
+    }
+    
+    // Prepare result
+    result := Result{
+        RunID:         runID,
+        Workers:       *workers,
+        WorkersAuto:   workerCount.auto && !*sequential,
+        Algorithm:     algorithm,
+        Verdict:       primefinder.AlgorithmVerdict(algorithm, *mrRounds),
+        Wheel:         *wheel,
+        Deterministic: *deterministic,
+        Cancelled:     cancelled,
+        Unordered:     *unordered && !*sequential,
+        CountOnly:     *countOnly,
+        Limit:         *limit,
+        Descending:    *descending,
+        Stride:        *stride,
+        Offset:        *offset,
+        Features:      features.names(),
+    }
+    if !*sequential {
+        result.Executor = *executor
+    }
+    if *deterministic {
+        result.Seed = *seed
+    }
+    if resumed != nil {
+        result.ResumedFrom, _ = resumed.remaining()
+    }
+    if *transforms != "" {
+        result.Transforms = *transforms
+    }
+    
+    rangeResults := make([]RangeResult, len(ranges))
+    var totalDuration time.Duration
+    for i, r := range ranges {
+        search := searches[i]
+        found := search.priorPrimes + search.count
+        duration := search.priorDuration + search.duration
+        totalDuration += duration
+        rr := RangeResult{
+            StartRange:        r[0],
+            EndRange:          r[1],
+            PrimesFound:       found,
+            ExecutionTime:     duration.Seconds(),
+            QuarantinedChunks: search.quarantined,
+            UnsearchedChunks:  search.unsearched,
+            WorkerUtilization: workerUtilization(search.workers),
+            WorkerCounts:      workerCounts(search.timeline),
+        }
+        if *rangeSpec != "" {
+            fmt.Printf("[%d, %d]: ", r[0], r[1])
+        }
+        fmt.Println(tr("Found %d primes in %v", found, duration))
+    
+        if search.deadline {
+            rr.DeadlineReached = true
+            complete := [2]int{r[0], search.covered}
+            if *descending {
+                complete = [2]int{search.covered, r[1]}
+            }
+            if complete[0] <= complete[1] {
+                rr.CompletePrefix = &complete
+                fmt.Println(tr("Soft deadline reached; [%d, %d] is complete with %d primes", complete[0], complete[1], search.priorPrimes+search.coveredPrimes))
+            } else {
+                fmt.Println(tr("Soft deadline reached before any prefix of the range was complete"))
+            }
+        }
+        if len(search.unsearched) > 0 {
+            if search.deadline {
+                fmt.Println(tr("Warning: %d ranges were not searched before the soft deadline:", len(search.unsearched)))
+            } else {
+                fmt.Println(tr("Warning: %d ranges were not searched before cancellation:", len(search.unsearched)))
+            }
+            for _, u := range search.unsearched {
+                fmt.Printf("  [%d, %d]\n", u[0], u[1])
+            }
+        } else if search.limited {
+            rr.LimitReached = true
+            complete := [2]int{r[0], search.covered}
+            if *descending {
+                complete = [2]int{search.covered, r[1]}
+            }
+            rr.CompletePrefix = &complete
+            fmt.Println(tr("Limit of %d primes reached at %d", *limit, search.covered))
+        } else if *stride > 1 {
+            // Known counts are for every number in the range
+        } else if check := primefinder.CheckKnownCount(r[0], r[1], found); check != nil {
+            rr.KnownValueCheck = check
+            if check.Matched {
+                fmt.Println(tr("Count matches known value pi = %d", check.Expected))
+            } else {
+                fmt.Println(tr("*** MISMATCH: found %d primes but the known count for this range is %d ***",
+                    check.Actual, check.Expected))
+            }
+        }
+    
+        if len(search.quarantined) > 0 {
+            fmt.Println(tr("Warning: %d chunks quarantined after repeated timeouts; primes in them are missing:", len(search.quarantined)))
+            for _, q := range search.quarantined {
+                fmt.Printf("  [%d, %d]\n", q[0], q[1])
+            }
+        }
+    
+        if search.pairs != nil {
+            rr.Pairs = search.pairs
+            fmt.Println(tr("Found %d %s prime pairs", rr.Pairs.Count, *pairKind))
+        }
+        if search.gaps != nil {
+            report := search.gaps.Report()
+            rr.Gaps = &report
+            if report.MaxGapAt != nil {
+                fmt.Println(tr("Largest gap %d between %d and %d, average gap %.2f", report.MaxGap, report.MaxGapAt[0], report.MaxGapAt[1], report.AverageGap))
+            }
+        }
+    
+        if *transforms != "" {
+            rr.PrimesEmitted = len(search.primes)
+            fmt.Println(tr("Transforms emitted %d primes", rr.PrimesEmitted))
+        }
+        if *savePrimes {
+            rr.Primes = search.primes
+        }
+        rangeResults[i] = rr
+    }
+    if *verbose {
+        printGCReport(gcBefore, takeGCSnapshot())
+    }
+    
+    if *rangeSpec == "" {
+        rr := rangeResults[0]
+        result.StartRange, result.EndRange = rr.StartRange, rr.EndRange
+        result.PrimesFound, result.ExecutionTime = rr.PrimesFound, rr.ExecutionTime
+        result.PrimesEmitted, result.QuarantinedChunks = rr.PrimesEmitted, rr.QuarantinedChunks
+        result.KnownValueCheck, result.Primes = rr.KnownValueCheck, rr.Primes
+        result.UnsearchedChunks = rr.UnsearchedChunks
+        result.WorkerUtilization, result.WorkerCounts = rr.WorkerUtilization, rr.WorkerCounts
+        result.DeadlineReached, result.CompletePrefix = rr.DeadlineReached, rr.CompletePrefix
+        result.LimitReached = rr.LimitReached
+        result.Pairs, result.Gaps = rr.Pairs, rr.Gaps
+    } else {
+        // Top-level fields span all ranges so single-range readers still
+        // see the totals; primes are only reported per range
+        result.Ranges = rangeResults
+        result.Summary = summarizeRanges(rangeResults)
+        result.StartRange, result.EndRange = ranges[0][0], maxEnd
+        for _, r := range ranges {
+            result.StartRange = min(result.StartRange, r[0])
+        }
+        result.PrimesFound, result.ExecutionTime = result.Summary.PrimesFound, result.Summary.ExecutionTime
+        result.PrimesEmitted = result.Summary.PrimesEmitted
+        result.DeadlineReached = deadlineReached
+        fmt.Println(tr("Total: %d primes in %d ranges (%.4gs)", result.PrimesFound, len(ranges), result.ExecutionTime))
+    }
+    
+    result.DurationNS, result.Duration = totalDuration.Nanoseconds(), totalDuration.String()
+    result.Timings = phases.timings(*timeUnit)
+    result.Timings.Encode = encodePlaceholder
+    
+    // Save results, encoding them first so the encode time can go in them
+    file, err := os.Create(*output)
+    if err != nil {
+        return fmt.Errorf("%w: %v", primefinder.ErrSinkWrite, err)
+    }
+    defer file.Close()
+    
+    var encoded bytes.Buffer
+    encodeStart := time.Now()
+    if err := writer.WriteResult(&encoded, result); err != nil {
+        return fmt.Errorf("%w: %s: %v", primefinder.ErrSinkWrite, *output, err)
+    }
+    phases.Encode = time.Since(encodeStart)
+    data := fillEncodeTime(encoded.Bytes(), inUnit(phases.Encode, *timeUnit))
+    writeStart := time.Now()
+    if _, err := file.Write(data); err != nil {
+        return fmt.Errorf("%w: %s: %v", primefinder.ErrSinkWrite, *output, err)
+    }
+    phases.Write = time.Since(writeStart)
+    
+    fmt.Println(tr("Results saved to %s", *output))
+    wall := totalDuration + phases.Encode + phases.Write
+    if *verbose {
+        fmt.Println(tr("Timings: %s", phases.breakdown(wall)))
+    }
+    if hint := phases.bottleneckHint(wall, *format); hint != "" {
+        fmt.Println(tr("Hint: %s", hint))
+    }
+    if cancelled {
+        return fmt.Errorf("%w: partial results saved to %s", primefinder.ErrCancelled, *output)
+    }
+    return nil
+}
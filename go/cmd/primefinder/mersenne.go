@@ -0,0 +1,63 @@
+// mersenne.go
+package main
+
+import (
+    "errors"
+    "fmt"
+    "time"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+// MersennePrime is a Mersenne prime 2^p-1 found by -mersenne
+type MersennePrime struct {
+    Exponent int `json:"exponent"`
+    Digits   int `json:"digits"`
+}
+
+// MersenneResult reports a -mersenne search
+type MersenneResult struct {
+    RunID           string          `json:"run_id"`
+    MaxExponent     int             `json:"max_exponent"`
+    TestedThrough   int             `json:"tested_through"`
+    ExponentsTested int             `json:"exponents_tested"` // prime exponents given the Lucas-Lehmer test
+    Found           []MersennePrime `json:"found"`
+    Workers         int             `json:"workers"`
+    ExecutionTime   float64         `json:"execution_time_seconds"`
+    Cancelled       bool            `json:"cancelled,omitempty"`
+}
+
+// runMersenne implements `-mersenne -max-exponent P`: the Lucas-Lehmer test
+// of 2^p-1 for every prime p up to P, one exponent per worker at a time
+func runMersenne(maxExponent, workers int, output string) error {
+    runID := newRunID()
+    output = expandRunID(output, runID)
+    fmt.Println(tr("Run %s: testing 2^p-1 for prime p up to %d with %d workers...", runID, maxExponent, workers))
+    
+    ctx, stop := signalContext()
+    defer stop()
+    result := MersenneResult{RunID: runID, MaxExponent: maxExponent, Found: []MersennePrime{}, Workers: workers}
+    started := time.Now()
+    through, err := primefinder.FindMersenne(ctx, 2, maxExponent, workers, func(r primefinder.FamilyResult) {
+        if !primefinder.IsPrime(r.X) {
+            return
+        }
+        result.ExponentsTested++
+        if r.Prime {
+            found := MersennePrime{Exponent: r.X, Digits: primefinder.MersenneDigits(r.X)}
+            result.Found = append(result.Found, found)
+            fmt.Println(tr("2^%d-1 is prime (%d digits)", found.Exponent, found.Digits))
+        }
+    })
+    duration := time.Since(started)
+    result.TestedThrough, result.ExecutionTime = through, duration.Seconds()
+    result.Cancelled = errors.Is(err, primefinder.ErrCancelled)
+    if err != nil && !result.Cancelled {
+        return err
+    }
+    fmt.Println(tr("Found %d Mersenne primes among %d prime exponents in %v", len(result.Found), result.ExponentsTested, duration))
+    if saveErr := saveResult(output, result); saveErr != nil {
+        return saveErr
+    }
+    return err
+}
@@ -0,0 +1,99 @@
+// nth.go
+package main
+
+import (
+    "context"
+    "flag"
+    "fmt"
+    "os"
+    "os/signal"
+    "runtime"
+    "syscall"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+// searchFlags registers the -workers, -algorithm and -force-algorithm flags
+// shared by nthprime and count
+func searchFlags(fs *flag.FlagSet) (workers *int, algorithm *string, force *bool) {
+    workers = fs.Int("workers", runtime.NumCPU(), "Number of workers")
+    algorithm = fs.String("algorithm", primefinder.AlgorithmAuto, "Search algorithm: trial, sieve, miller-rabin, or auto (sieve from 1e7)")
+    force = fs.Bool("force-algorithm", false, fmt.Sprintf("Run trial division even when it is estimated to take over %v", maxTrialEstimate))
+    return workers, algorithm, force
+}
+
+// checkAlgorithm checks the algorithm for a search ending at end, refusing
+// trial division that would take too long unless forced
+func checkAlgorithm(algorithm string, end, workers int, force bool) error {
+    resolved, err := primefinder.ResolveAlgorithm(algorithm, end)
+    if err != nil || resolved != primefinder.AlgorithmTrial || force {
+        return err
+    }
+    return checkTrialDivision([][2]int{{2, end}}, 0, workers, 0)
+}
+
+// signalContext returns a context cancelled by SIGINT or SIGTERM
+func signalContext() (context.Context, context.CancelFunc) {
+    return signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+}
+
+// runNthPrime implements `nthprime N`
+func runNthPrime(args []string) error {
+    fs := flag.NewFlagSet("nthprime", flag.ExitOnError)
+    workers, algorithm, force := searchFlags(fs)
+    fs.Usage = func() {
+        fmt.Fprintln(fs.Output(), "Usage: nthprime [flags] N")
+        fs.PrintDefaults()
+    }
+    if err := parseFlags(fs, args); err != nil {
+        return err
+    }
+    
+    if fs.NArg() != 1 {
+        fs.Usage()
+        return fmt.Errorf("%w: nthprime needs exactly one N", primefinder.ErrInvalidArgument)
+    }
+    n, err := parseNumber(fs.Arg(0))
+    if err != nil {
+        return err
+    }
+    _, hi := primefinder.NthPrimeBounds(max(n, 1))
+    if err := checkAlgorithm(*algorithm, hi, *workers, *force); err != nil {
+        return err
+    }
+    ctx, stop := signalContext()
+    defer stop()
+    p, err := primefinder.NthPrime(ctx, n, *workers, primefinder.Config{Algorithm: *algorithm})
+    if err != nil {
+        return err
+    }
+    fmt.Println(p)
+    return nil
+}
+
+// runCount implements `count -end X`, printing π(X)
+func runCount(args []string) error {
+    fs := flag.NewFlagSet("count", flag.ExitOnError)
+    workers, algorithm, force := searchFlags(fs)
+    end := numberFlag(0)
+    fs.Var(&end, "end", "Count the primes up to this (accepts 1e15 and 1e15+1e9 forms)")
+    if err := parseFlags(fs, args); err != nil {
+        return err
+    }
+    
+    if end < 1 {
+        return fmt.Errorf("%w: count needs -end X, counting the primes up to X", primefinder.ErrInvalidArgument)
+    }
+    if err := checkAlgorithm(*algorithm, int(end), *workers, *force); err != nil {
+        return err
+    }
+    
+    ctx, stop := signalContext()
+    defer stop()
+    count, err := primefinder.PrimePi(ctx, int(end), *workers, primefinder.Config{Algorithm: *algorithm})
+    if err != nil {
+        return err
+    }
+    fmt.Println(count)
+    return nil
+}
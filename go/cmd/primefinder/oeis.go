@@ -0,0 +1,133 @@
+// oeis.go
+package main
+
+import (
+    "flag"
+    "fmt"
+    "math/big"
+    "runtime"
+    "sort"
+    "sync"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+// oeisSequence is a curated OEIS sequence whose terms are the primes that
+// satisfy member
+type oeisSequence struct {
+    name   string
+    member func(p int) bool
+}
+
+var oeisSequences = map[string]oeisSequence{
+    "A000040": {"The prime numbers", func(p int) bool { return true }},
+    "A000043": {"Mersenne exponents: primes p such that 2^p-1 is prime", isMersenneExponent},
+    "A001359": {"Lesser of twin primes", func(p int) bool { return primefinder.IsProbablePrime(p + 2) }},
+    "A002496": {"Primes of the form n^2+1", func(p int) bool { return isSquare(p - 1) }},
+    "A005384": {"Sophie Germain primes p: 2p+1 is also prime", isSophieGermainPrime},
+    "A005385": {"Safe primes p: (p-1)/2 is also prime", isSafePrime},
+    "A023200": {"Lesser of cousin primes (p, p+4)", func(p int) bool { return primefinder.IsProbablePrime(p + 4) }},
+    "A023201": {"Lesser of sexy primes (p, p+6)", func(p int) bool { return primefinder.IsProbablePrime(p + 6) }},
+}
+
+// isSophieGermainPrime reports whether 2p+1 is prime for prime p
+func isSophieGermainPrime(p int) bool {
+    return primefinder.IsProbablePrime(2*p + 1)
+}
+
+// isSafePrime reports whether (p-1)/2 is prime for prime p
+func isSafePrime(p int) bool {
+    return p > 2 && primefinder.IsProbablePrime((p-1)/2)
+}
+
+// isMersenneExponent reports whether 2^p-1 is prime for prime p
+func isMersenneExponent(p int) bool {
+    m := new(big.Int).Lsh(big.NewInt(1), uint(p))
+    m.Sub(m, big.NewInt(1))
+    return m.ProbablyPrime(0)
+}
+
+// isSquare reports whether n is a perfect square
+func isSquare(n int) bool {
+    if n < 0 {
+        return false
+    }
+    r := isqrt(n)
+    return r*r == n
+}
+
+// isqrt returns the floor of the square root of n
+func isqrt(n int) int {
+    return int(new(big.Int).Sqrt(big.NewInt(int64(n))).Int64())
+}
+
+// filterParallel keeps the primes satisfying member, testing them across
+// workers and preserving order
+func filterParallel(primes []int, workers int, member func(int) bool) []int {
+    keep := make([]bool, len(primes))
+    jobs := make(chan int, workers)
+    var wg sync.WaitGroup
+    for w := 0; w < workers; w++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for i := range jobs {
+                keep[i] = member(primes[i])
+            }
+        }()
+    }
+    for i := range primes {
+        jobs <- i
+    }
+    close(jobs)
+    wg.Wait()
+    
+    var terms []int
+    for i, p := range primes {
+        if keep[i] {
+            terms = append(terms, p)
+        }
+    }
+    return terms
+}
+
+// runOEIS implements `oeis A000043`
+func runOEIS(args []string) error {
+    fs := flag.NewFlagSet("oeis", flag.ExitOnError)
+    start := fs.Int("start", 1, "Smallest term to output")
+    end := fs.Int("end", 1000, "Largest term to output")
+    workers := fs.Int("workers", runtime.NumCPU(), "Number of workers")
+    list := fs.Bool("list", false, "List the supported sequences")
+    if err := parseFlags(fs, args); err != nil {
+        return err
+    }
+    
+    if *list {
+        ids := make([]string, 0, len(oeisSequences))
+        for id := range oeisSequences {
+            ids = append(ids, id)
+        }
+        sort.Strings(ids)
+        for _, id := range ids {
+            fmt.Printf("%s  %s\n", id, oeisSequences[id].name)
+        }
+        return nil
+    }
+    
+    if fs.NArg() != 1 {
+        return fmt.Errorf("%w: usage: oeis [-start N] [-end N] A-number (see -list)", primefinder.ErrInvalidArgument)
+    }
+    seq, ok := oeisSequences[fs.Arg(0)]
+    if !ok {
+        return fmt.Errorf("%w: unsupported sequence %s (see -list)", primefinder.ErrInvalidArgument, fs.Arg(0))
+    }
+    if err := primefinder.ValidateRange(*start, *end, *workers); err != nil {
+        return err
+    }
+    
+    primes, _ := primefinder.FindRangeConcurrent(*start, *end, *workers)
+    for _, term := range filterParallel(primes, *workers, seq.member) {
+        fmt.Println(term)
+    }
+    return nil
+}
@@ -0,0 +1,32 @@
+// oeis_test.go
+package main
+
+import (
+    "reflect"
+    "testing"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+func TestOEISSequences(t *testing.T) {
+    tests := []struct {
+        id       string
+        end      int
+        expected []int
+    }{
+        {"A000043", 130, []int{2, 3, 5, 7, 13, 17, 19, 31, 61, 89, 107, 127}},
+        {"A001359", 45, []int{3, 5, 11, 17, 29, 41}},
+        {"A002496", 300, []int{2, 5, 17, 37, 101, 197, 257}},
+        {"A005384", 60, []int{2, 3, 5, 11, 23, 29, 41, 53}},
+        {"A005385", 200, []int{5, 7, 11, 23, 47, 59, 83, 107, 167, 179}},
+        {"A023200", 50, []int{3, 7, 13, 19, 37, 43}},
+    }
+    
+    for _, tt := range tests {
+        primes, _ := primefinder.FindRangeConcurrent(1, tt.end, 3)
+        got := filterParallel(primes, 3, oeisSequences[tt.id].member)
+        if !reflect.DeepEqual(got, tt.expected) {
+            t.Errorf("%s up to %d = %v, expected %v", tt.id, tt.end, got, tt.expected)
+        }
+    }
+}
@@ -0,0 +1,209 @@
+// output.go
+package main
+
+import (
+    "bufio"
+    "encoding/binary"
+    "encoding/csv"
+    "errors"
+    "flag"
+    "fmt"
+    "io"
+    "strconv"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+// OutputWriter writes the result of a search in one output format
+type OutputWriter interface {
+    WriteResult(w io.Writer, result Result) error
+}
+
+// outputFormats lists the formats selectable with -format and the extension
+// of their default output file
+var outputFormats = map[string]string{
+    "json":   "json",   // the full result document
+    "csv":    "csv",    // one prime per row
+    "ndjson": "ndjson", // one JSON object per prime
+    "bin":    "bin",    // delta-encoded varints
+}
+
+// newOutputWriter returns the writer for a -format value. compat is the
+// -json-compat mode, which only the json format accepts.
+func newOutputWriter(format, compat string) (OutputWriter, error) {
+    if compat != "" && format != "json" {
+        return nil, fmt.Errorf("%w: -json-compat needs -format=json", primefinder.ErrInvalidArgument)
+    }
+    switch format {
+    case "json":
+        return jsonWriter{compat: compat}, nil
+    case "csv":
+        return csvWriter{}, nil
+    case "ndjson":
+        return ndjsonWriter{}, nil
+    case "bin":
+        return binaryWriter{}, nil
+    }
+    return nil, fmt.Errorf("%w: unknown -format %q (available: json, csv, ndjson, bin)", primefinder.ErrInvalidArgument, format)
+}
+
+// primeRanges returns the ranges of a result with their primes, taking the
+// top-level range when the result has no per-range breakdown
+func primeRanges(result Result) []RangeResult {
+    if len(result.Ranges) > 0 {
+        return result.Ranges
+    }
+    return []RangeResult{{StartRange: result.StartRange, EndRange: result.EndRange, Primes: result.Primes}}
+}
+
+// jsonWriter writes the whole result as indented JSON
+type jsonWriter struct {
+    compat string // -json-compat mode
+}
+
+func (j jsonWriter) WriteResult(w io.Writer, result Result) error {
+    return writeJSON(w, result, j.compat)
+}
+
+// csvWriter writes a header and one row per prime. Rows of a -ranges run
+// also name the range the prime was found in.
+type csvWriter struct{}
+
+func (csvWriter) WriteResult(w io.Writer, result Result) error {
+    out := csv.NewWriter(w)
+    withRange := len(result.Ranges) > 0
+    if withRange {
+        out.Write([]string{"start_range", "end_range", "prime"})
+    } else {
+        out.Write([]string{"prime"})
+    }
+    for _, r := range primeRanges(result) {
+        for _, p := range r.Primes {
+            if withRange {
+                out.Write([]string{strconv.Itoa(r.StartRange), strconv.Itoa(r.EndRange), strconv.Itoa(p)})
+            } else {
+                out.Write([]string{strconv.Itoa(p)})
+            }
+        }
+    }
+    out.Flush()
+    return out.Error()
+}
+
+// ndjsonWriter writes one JSON object per line for each prime, with its
+// range for a -ranges run
+type ndjsonWriter struct{}
+
+func (ndjsonWriter) WriteResult(w io.Writer, result Result) error {
+    out := bufio.NewWriter(w)
+    withRange := len(result.Ranges) > 0
+    for _, r := range primeRanges(result) {
+        for _, p := range r.Primes {
+            if withRange {
+                fmt.Fprintf(out, "{\"start_range\":%d,\"end_range\":%d,\"prime\":%d}\n", r.StartRange, r.EndRange, p)
+            } else {
+                fmt.Fprintf(out, "{\"prime\":%d}\n", p)
+            }
+        }
+    }
+    return out.Flush()
+}
+
+// binaryMagic starts every file written by binaryWriter; its last byte is
+// the format version
+var binaryMagic = []byte("PFB\x02")
+
+// binaryMagicV1 starts files of the first format version, which has no
+// completeness record
+var binaryMagicV1 = []byte("PFB\x01")
+
+// binaryWriter writes binaryMagic, then why the result cannot answer
+// queries about its ranges as a length-prefixed string, empty if it can (see
+// incompleteReason), and then for each range its start, end and prime count
+// followed by each prime as its difference from the previous one (from the
+// range start for the first). All numbers are zigzag varints as written by
+// binary.AppendVarint, so descending results encode as compactly as
+// ascending ones.
+type binaryWriter struct{}
+
+func (binaryWriter) WriteResult(w io.Writer, result Result) error {
+    out := bufio.NewWriter(w)
+    out.Write(binaryMagic)
+    reason := incompleteReason(&result)
+    buf := make([]byte, 0, 3*binary.MaxVarintLen64)
+    out.Write(binary.AppendUvarint(buf[:0], uint64(len(reason))))
+    out.WriteString(reason)
+    for _, r := range primeRanges(result) {
+        buf = binary.AppendVarint(buf[:0], int64(r.StartRange))
+        buf = binary.AppendVarint(buf, int64(r.EndRange))
+        buf = binary.AppendVarint(buf, int64(len(r.Primes)))
+        out.Write(buf)
+        prev := r.StartRange
+        for _, p := range r.Primes {
+            out.Write(binary.AppendVarint(buf[:0], int64(p-prev)))
+            prev = p
+        }
+    }
+    return out.Flush()
+}
+
+// readBinaryPrimes decodes a file written by binaryWriter into its ranges
+// and their primes. incomplete says why the ranges were not searched in full
+// or not all their primes kept, and is empty if they were; files of the
+// first version, which do not record this, are reported as incomplete.
+func readBinaryPrimes(r io.Reader) (ranges []RangeResult, incomplete string, err error) {
+    in := bufio.NewReader(r)
+    magic := make([]byte, len(binaryMagic))
+    if _, err := io.ReadFull(in, magic); err != nil {
+        return nil, "", errors.New("not a primefinder binary file")
+    }
+    switch string(magic) {
+    case string(binaryMagic):
+        length, err := binary.ReadUvarint(in)
+        if err != nil || length > 1024 {
+            return nil, "", fmt.Errorf("bad completeness record: %v", err)
+        }
+        reason := make([]byte, length)
+        if _, err := io.ReadFull(in, reason); err != nil {
+            return nil, "", fmt.Errorf("truncated completeness record: %v", err)
+        }
+        incomplete = string(reason)
+    case string(binaryMagicV1):
+        incomplete = "no completeness record (written by an older version; rerun to regenerate)"
+    default:
+        return nil, "", errors.New("not a primefinder binary file")
+    }
+    
+    for {
+        start, err := binary.ReadVarint(in)
+        if err == io.EOF {
+            return ranges, incomplete, nil
+        }
+        end, err2 := binary.ReadVarint(in)
+        count, err3 := binary.ReadVarint(in)
+        if err = errors.Join(err, err2, err3); err != nil || count < 0 {
+            return nil, "", fmt.Errorf("truncated range header: %v", err)
+        }
+    
+        rr := RangeResult{StartRange: int(start), EndRange: int(end), PrimesFound: int(count)}
+        prev := start
+        for i := int64(0); i < count; i++ {
+            delta, err := binary.ReadVarint(in)
+            if err != nil {
+                return nil, "", fmt.Errorf("truncated primes of [%d, %d]: %v", start, end, err)
+            }
+            prev += delta
+            rr.Primes = append(rr.Primes, int(prev))
+        }
+        ranges = append(ranges, rr)
+    }
+}
+
+// flagSet reports whether the named flag was given on the command line
+func flagSet(fs *flag.FlagSet, name string) bool {
+    found := false
+    fs.Visit(func(f *flag.Flag) {
+        found = found || f.Name == name
+    })
+    return found
+}
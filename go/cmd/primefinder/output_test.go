@@ -0,0 +1,97 @@
+// output_test.go
+package main
+
+import (
+    "bytes"
+    "errors"
+    "reflect"
+    "testing"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+func TestOutputWriters(t *testing.T) {
+    single := Result{StartRange: 10, EndRange: 30, Primes: []int{11, 13, 17, 19, 23, 29}}
+    ranges := Result{Ranges: []RangeResult{
+        {StartRange: 1, EndRange: 10, Primes: []int{2, 3}},
+        {StartRange: 100, EndRange: 105, Primes: []int{101}},
+    }}
+    tests := []struct {
+        format   string
+        result   Result
+        expected string
+    }{
+        {"csv", single, "prime\n11\n13\n17\n19\n23\n29\n"},
+        {"csv", ranges, "start_range,end_range,prime\n1,10,2\n1,10,3\n100,105,101\n"},
+        {"ndjson", Result{Primes: []int{2, 3}}, "{\"prime\":2}\n{\"prime\":3}\n"},
+        {"ndjson", ranges, "{\"start_range\":1,\"end_range\":10,\"prime\":2}\n{\"start_range\":1,\"end_range\":10,\"prime\":3}\n{\"start_range\":100,\"end_range\":105,\"prime\":101}\n"},
+    }
+    for _, tt := range tests {
+        writer, err := newOutputWriter(tt.format, "")
+        if err != nil {
+            t.Fatal(err)
+        }
+        var buf bytes.Buffer
+        if err := writer.WriteResult(&buf, tt.result); err != nil || buf.String() != tt.expected {
+            t.Errorf("%s output = %q, %v; expected %q", tt.format, buf.String(), err, tt.expected)
+        }
+    }
+}
+
+func TestBinaryOutputRoundTrip(t *testing.T) {
+    result := Result{Ranges: []RangeResult{
+        {StartRange: 1, EndRange: 30, Primes: []int{2, 3, 5, 7, 11, 13, 17, 19, 23, 29}},
+        {StartRange: 1000000, EndRange: 1000100, Primes: []int{1000099, 1000081, 1000039, 1000037, 1000033, 1000003}}, // descending
+        {StartRange: 24, EndRange: 28},
+    }}
+    var buf bytes.Buffer
+    if err := (binaryWriter{}).WriteResult(&buf, result); err != nil {
+        t.Fatal(err)
+    }
+    // Small gaps take one byte each
+    if size := buf.Len(); size > 60 {
+        t.Errorf("Binary output is %d bytes, expected at most 60", size)
+    }
+    
+    ranges, incomplete, err := readBinaryPrimes(&buf)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if incomplete != "not a search result" {
+        t.Errorf("completeness record %q, expected the result without a run ID to be recorded as incomplete", incomplete)
+    }
+    for i := range result.Ranges {
+        result.Ranges[i].PrimesFound = len(result.Ranges[i].Primes)
+    }
+    if !reflect.DeepEqual(ranges, result.Ranges) {
+        t.Errorf("readBinaryPrimes = %+v, expected %+v", ranges, result.Ranges)
+    }
+    
+    if _, _, err := readBinaryPrimes(bytes.NewReader([]byte("PFB\x02\x00\x02\x3c\x04\x02"))); err == nil {
+        t.Error("Expected an error for truncated primes")
+    }
+    if _, incomplete, err := readBinaryPrimes(bytes.NewReader([]byte("PFB\x01\x02\x3c\x02\x04"))); err != nil || incomplete == "" {
+        t.Errorf("version 1 file: got %q, %v; expected it read and reported incomplete", incomplete, err)
+    }
+    if _, _, err := readBinaryPrimes(bytes.NewReader([]byte("{}"))); err == nil {
+        t.Error("Expected an error for a file without the magic")
+    }
+}
+
+func TestNewOutputWriter(t *testing.T) {
+    tests := []struct {
+        format, compat string
+        err            error
+    }{
+        {"json", "", nil},
+        {"json", "js", nil},
+        {"bin", "", nil},
+        {"csv", "js", primefinder.ErrInvalidArgument},
+        {"xml", "", primefinder.ErrInvalidArgument},
+    }
+    for _, tt := range tests {
+        if _, err := newOutputWriter(tt.format, tt.compat); !errors.Is(err, tt.err) {
+            t.Errorf("newOutputWriter(%q, %q) error = %v, expected %v", tt.format, tt.compat, err, tt.err)
+        }
+    }
+}
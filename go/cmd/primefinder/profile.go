@@ -0,0 +1,59 @@
+// profile.go
+package main
+
+import (
+    "fmt"
+    "os"
+    "path/filepath"
+    "runtime"
+    "runtime/pprof"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+// startProfiles begins a CPU profile for one run, written to
+// <dir>/<runID>.cpu.pprof. The returned stop function ends the CPU profile
+// and writes a heap profile to <dir>/<runID>.heap.pprof; calling it again
+// does nothing, so it can be deferred to cover early returns as well.
+func startProfiles(dir, runID string) (stop func() error, err error) {
+    if err := os.MkdirAll(dir, 0o755); err != nil {
+        return nil, fmt.Errorf("%w: %v", primefinder.ErrSinkWrite, err)
+    }
+    
+    cpuPath := filepath.Join(dir, runID+".cpu.pprof")
+    cpuFile, err := os.Create(cpuPath)
+    if err != nil {
+        return nil, fmt.Errorf("%w: %v", primefinder.ErrSinkWrite, err)
+    }
+    if err := pprof.StartCPUProfile(cpuFile); err != nil {
+        cpuFile.Close()
+        return nil, err
+    }
+    
+    stopped := false
+    return func() error {
+        if stopped {
+            return nil
+        }
+        stopped = true
+        pprof.StopCPUProfile()
+        if err := cpuFile.Close(); err != nil {
+            return fmt.Errorf("%w: %v", primefinder.ErrSinkWrite, err)
+        }
+        
+        heapPath := filepath.Join(dir, runID+".heap.pprof")
+        heapFile, err := os.Create(heapPath)
+        if err != nil {
+            return fmt.Errorf("%w: %v", primefinder.ErrSinkWrite, err)
+        }
+        defer heapFile.Close()
+        
+        // Collect first so the profile reflects live objects
+        runtime.GC()
+        if err := pprof.WriteHeapProfile(heapFile); err != nil {
+            return fmt.Errorf("%w: %v", primefinder.ErrSinkWrite, err)
+        }
+        fmt.Println(tr("Profiles written to %s and %s", cpuPath, heapPath))
+        return nil
+    }, nil
+}
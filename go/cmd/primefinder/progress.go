@@ -0,0 +1,177 @@
+// progress.go
+package main
+
+import (
+    "fmt"
+    "io"
+    "os"
+    "strings"
+    "sync"
+    "time"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+// Progress display modes selectable with -progress
+const (
+    progressOff   = "off"   // no progress output
+    progressAuto  = "auto"  // tty on a terminal, plain otherwise
+    progressTTY   = "tty"   // one status line redrawn in place
+    progressPlain = "plain" // one line per update, no control codes
+)
+
+// plainInterval is the minimum time between plain progress lines, and the
+// period of the lines written while no chunk completes
+const plainInterval = time.Second
+
+// ttyInterval is how often the tty status line is redrawn so elapsed time
+// and the ETA keep moving between chunk completions
+const ttyInterval = 250 * time.Millisecond
+
+// progressBarWidth is the number of cells in the tty progress bar
+const progressBarWidth = 30
+
+// resolveProgressMode returns the display mode for -progress, picking plain
+// output when f is not a terminal
+func resolveProgressMode(mode string, f *os.File) (string, error) {
+    switch mode {
+    case progressOff, progressTTY, progressPlain:
+        return mode, nil
+    case progressAuto:
+        if isTerminal(f) {
+            return progressTTY, nil
+        }
+        return progressPlain, nil
+    }
+    return "", fmt.Errorf("%w: unknown -progress mode %q", primefinder.ErrInvalidArgument, mode)
+}
+
+// isTerminal reports whether f is a character device such as a terminal
+func isTerminal(f *os.File) bool {
+    info, err := f.Stat()
+    return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// progressReporter renders search progress. In tty mode the status line is
+// redrawn on every update and on a ticker; in plain mode lines are throttled
+// to one per plainInterval, except the final one, and repeated on a ticker
+// while no chunk completes.
+type progressReporter struct {
+    w     io.Writer
+    mode  string
+    label string // prefix identifying the range, if any
+    
+    mu      sync.Mutex
+    latest  primefinder.Progress
+    updated time.Time // when latest arrived
+    last    time.Time // when a line was last written
+    done    bool
+    
+    stopTicker chan struct{}
+    ticking    sync.WaitGroup
+}
+
+// start begins redrawing on a ticker until stop is called
+func (r *progressReporter) start() {
+    interval := plainInterval
+    if r.mode == progressTTY {
+        interval = ttyInterval
+    }
+    r.stopTicker = make(chan struct{})
+    r.ticking.Add(1)
+    go func() {
+        defer r.ticking.Done()
+        ticker := time.NewTicker(interval)
+        defer ticker.Stop()
+        for {
+            select {
+            case <-ticker.C:
+                r.tick()
+            case <-r.stopTicker:
+                return
+            }
+        }
+    }()
+}
+
+// stop ends the ticker started by start
+func (r *progressReporter) stop() {
+    if r.stopTicker != nil {
+        close(r.stopTicker)
+        r.ticking.Wait()
+        r.stopTicker = nil
+    }
+}
+
+// update records and renders one progress report
+func (r *progressReporter) update(p primefinder.Progress) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    now := time.Now()
+    r.latest, r.updated = p, now
+    r.done = p.ChunksDone == p.Chunks
+    if r.mode == progressTTY || r.done || now.Sub(r.last) >= plainInterval {
+        r.render(now)
+    }
+}
+
+// tick re-renders the latest report, if there is one and the search is not
+// done, so a slow chunk does not freeze the display
+func (r *progressReporter) tick() {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    now := time.Now()
+    if r.updated.IsZero() || r.done || (r.mode == progressPlain && now.Sub(r.last) < plainInterval) {
+        return
+    }
+    r.render(now)
+}
+
+// render writes the latest report as of now; r.mu must be held
+func (r *progressReporter) render(now time.Time) {
+    line := r.label + progressLine(r.latest, now.Sub(r.updated), r.mode == progressTTY)
+    switch r.mode {
+    case progressTTY:
+        // Return to the start of the line and clear it before redrawing
+        fmt.Fprintf(r.w, "\r\x1b[K%s", line)
+        if r.done {
+            fmt.Fprintln(r.w)
+        }
+    case progressPlain:
+        fmt.Fprintln(r.w, line)
+    }
+    r.last = now
+}
+
+// progressLine formats a progress report since seconds after it arrived,
+// with a bar if withBar is set. Throughput is primes per second so far; the
+// ETA extrapolates the rate at which completed chunks covered the range.
+func progressLine(p primefinder.Progress, since time.Duration, withBar bool) string {
+    fraction := float64(p.Searched) / float64(max(p.Width, 1))
+    elapsed := p.Elapsed + since
+    
+    var b strings.Builder
+    b.WriteString("progress: ")
+    if withBar {
+        filled := int(fraction * progressBarWidth)
+        fmt.Fprintf(&b, "[%s%s] ", strings.Repeat("#", filled), strings.Repeat("-", progressBarWidth-filled))
+    }
+    fmt.Fprintf(&b, "%.1f%% (%d/%d chunks), %d primes, %v", 100*fraction, p.ChunksDone, p.Chunks, p.Primes, elapsed.Round(time.Millisecond))
+    if elapsed > 0 {
+        fmt.Fprintf(&b, ", %.0f primes/s", float64(p.Primes)/elapsed.Seconds())
+    }
+    if eta, ok := progressETA(p, since); ok {
+        fmt.Fprintf(&b, ", ETA %v", eta.Round(time.Second))
+    }
+    return b.String()
+}
+
+// progressETA estimates the time left since after a report arrived; ok is
+// false until a chunk has completed
+func progressETA(p primefinder.Progress, since time.Duration) (eta time.Duration, ok bool) {
+    if p.Searched <= 0 || p.Elapsed <= 0 {
+        return 0, false
+    }
+    left := float64(p.Width-p.Searched) / float64(p.Searched) * float64(p.Elapsed)
+    return max(time.Duration(left)-since, 0), true
+}
@@ -0,0 +1,120 @@
+// progress_test.go
+package main
+
+import (
+    "bytes"
+    "errors"
+    "os"
+    "strings"
+    "sync"
+    "testing"
+    "time"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+func TestResolveProgressMode(t *testing.T) {
+    // A regular file is never a terminal
+    file, err := os.CreateTemp(t.TempDir(), "out")
+    if err != nil {
+        t.Fatal(err)
+    }
+    defer file.Close()
+    
+    tests := []struct {
+        mode     string
+        expected string
+        err      error
+    }{
+        {"auto", progressPlain, nil},
+        {"plain", progressPlain, nil},
+        {"tty", progressTTY, nil},
+        {"off", progressOff, nil},
+        {"fancy", "", primefinder.ErrInvalidArgument},
+    }
+    for _, tt := range tests {
+        got, err := resolveProgressMode(tt.mode, file)
+        if got != tt.expected || !errors.Is(err, tt.err) {
+            t.Errorf("resolveProgressMode(%q) = %q, %v; expected %q, %v", tt.mode, got, err, tt.expected, tt.err)
+        }
+    }
+}
+
+func TestPlainProgressHasNoControlCodes(t *testing.T) {
+    var buf bytes.Buffer
+    r := &progressReporter{w: &buf, mode: progressPlain}
+    for i := 1; i <= 4; i++ {
+        r.update(primefinder.Progress{ChunksDone: i, Chunks: 4, Searched: 25 * i, Width: 100, Primes: i})
+    }
+    
+    out := buf.String()
+    if strings.ContainsAny(out, "\r\x1b") {
+        t.Errorf("Plain progress contains control codes: %q", out)
+    }
+    // Updates within the throttle interval are dropped, but the final one
+    // is always written
+    lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+    if len(lines) != 2 || !strings.Contains(lines[1], "100.0% (4/4 chunks)") {
+        t.Errorf("Unexpected plain progress lines: %q", lines)
+    }
+}
+
+func TestProgressLine(t *testing.T) {
+    p := primefinder.Progress{ChunksDone: 1, Chunks: 4, Searched: 25, Width: 100, Primes: 50, Elapsed: 10 * time.Second}
+    tests := []struct {
+        name     string
+        since    time.Duration
+        withBar  bool
+        expected string
+    }{
+        {"plain", 0, false, "progress: 25.0% (1/4 chunks), 50 primes, 10s, 5 primes/s, ETA 30s"},
+        {"bar", 0, true, "progress: [#######-----------------------] 25.0% (1/4 chunks), 50 primes, 10s, 5 primes/s, ETA 30s"},
+        {"ticked", 10 * time.Second, false, "progress: 25.0% (1/4 chunks), 50 primes, 20s, 2 primes/s, ETA 20s"},
+        {"overdue", time.Minute, false, "progress: 25.0% (1/4 chunks), 50 primes, 1m10s, 1 primes/s, ETA 0s"},
+    }
+    for _, tt := range tests {
+        if got := progressLine(p, tt.since, tt.withBar); got != tt.expected {
+            t.Errorf("%s: progressLine = %q, expected %q", tt.name, got, tt.expected)
+        }
+    }
+    
+    // No ETA before a chunk completes
+    if got := progressLine(primefinder.Progress{Chunks: 4, Width: 100}, 0, false); strings.Contains(got, "ETA") {
+        t.Errorf("progressLine with nothing searched = %q, expected no ETA", got)
+    }
+}
+
+func TestProgressTicker(t *testing.T) {
+    var buf syncBuffer
+    r := &progressReporter{w: &buf, mode: progressTTY}
+    r.start()
+    r.update(primefinder.Progress{ChunksDone: 1, Chunks: 2, Searched: 50, Width: 100, Elapsed: time.Second})
+    time.Sleep(3 * ttyInterval)
+    r.update(primefinder.Progress{ChunksDone: 2, Chunks: 2, Searched: 100, Width: 100, Elapsed: 2 * time.Second})
+    r.stop()
+    
+    // The ticker redraws between the two updates, and the final update ends
+    // the line
+    out := buf.String()
+    if redraws := strings.Count(out, "\r\x1b[K"); redraws < 3 || !strings.HasSuffix(out, "\n") {
+        t.Errorf("Expected at least 3 redraws ending in a newline, got %q", out)
+    }
+}
+
+// syncBuffer is a bytes.Buffer safe for concurrent use
+type syncBuffer struct {
+    mu  sync.Mutex
+    buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+    b.mu.Lock()
+    defer b.mu.Unlock()
+    return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+    b.mu.Lock()
+    defer b.mu.Unlock()
+    return b.buf.String()
+}
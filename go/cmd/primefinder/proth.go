@@ -0,0 +1,70 @@
+// proth.go
+package main
+
+import (
+    "context"
+    "errors"
+    "flag"
+    "fmt"
+    "runtime"
+    "time"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+// ProthRun is the state and result of a proth scan
+type ProthRun struct {
+    K    int `json:"k"`
+    NMin int `json:"n_min"`
+    NMax int `json:"n_max"`
+    FamilyProgress
+}
+
+// runProth implements `proth`: test k*2^n+1 for each n in a range
+func runProth(args []string) error {
+    fs := flag.NewFlagSet("proth", flag.ExitOnError)
+    k := fs.Int("k", 3, "Odd multiplier k of k*2^n+1")
+    nMin := numberFlag(1)
+    nMax := numberFlag(1000)
+    fs.Var(&nMin, "n-min", "Smallest exponent n")
+    fs.Var(&nMax, "n-max", "Largest exponent n")
+    workers := fs.Int("workers", runtime.NumCPU(), "Number of workers, each testing one n at a time")
+    checkpoint := fs.String("checkpoint", "", "Save progress to this file periodically so -resume can continue the scan")
+    interval := fs.Duration("checkpoint-interval", 30*time.Second, "Time between checkpoint saves")
+    resume := fs.Bool("resume", false, "Continue the scan saved in the -checkpoint file (its k and n range replace those given)")
+    output := fs.String("output", "proth.json", "Output file")
+    if err := parseFlags(fs, args); err != nil {
+        return err
+    }
+    
+    run := ProthRun{K: *k, NMin: int(nMin), NMax: int(nMax)}
+    run.TestedThrough = run.NMin - 1
+    if *resume {
+        if *checkpoint == "" {
+            return fmt.Errorf("%w: -resume needs -checkpoint", primefinder.ErrInvalidArgument)
+        }
+        run = ProthRun{}
+        if err := loadState(*checkpoint, &run); err != nil {
+            return err
+        }
+        fmt.Println(tr("Resuming from %s: tested n up to %d", *checkpoint, run.TestedThrough))
+    }
+    
+    fmt.Println(tr("Testing %d*2^n+1 for n in [%d, %d] with %d workers...", run.K, run.NMin, run.NMax, *workers))
+    name := func(n int) string { return fmt.Sprintf("%d*2^%d+1", run.K, n) }
+    err := scanResumable(&run.FamilyProgress, &run, *checkpoint, *interval, name,
+        func(ctx context.Context, from int, onTested func(primefinder.FamilyResult)) (int, error) {
+            if from > run.NMax {
+                return run.NMax, nil
+            }
+            return primefinder.FindProth(ctx, run.K, from, run.NMax, *workers, onTested)
+        })
+    if err != nil && !errors.Is(err, primefinder.ErrCancelled) {
+        return err
+    }
+    fmt.Println(tr("Found %d primes in %v", len(run.Primes), time.Duration(run.ExecutionTime*float64(time.Second))))
+    if saveErr := saveResult(*output, run); saveErr != nil {
+        return saveErr
+    }
+    return err
+}
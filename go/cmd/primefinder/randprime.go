@@ -0,0 +1,129 @@
+// randprime.go
+package main
+
+import (
+    cryptorand "crypto/rand"
+    "flag"
+    "fmt"
+    "math"
+    "math/big"
+    "math/rand/v2"
+    "strconv"
+    "strings"
+    "time"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+// numberFlag is an int flag that also accepts scientific notation and sums,
+// e.g. "1e15" or "1e15+1e9"
+type numberFlag int
+
+func (n *numberFlag) String() string { return strconv.Itoa(int(*n)) }
+
+func (n *numberFlag) Set(s string) error {
+    v, err := parseNumber(s)
+    if err != nil {
+        return err
+    }
+    *n = numberFlag(v)
+    return nil
+}
+
+// parseNumber parses an integer written plainly, in scientific notation, or
+// as a sum of such terms. Numbers that do not fit in an int are refused
+// rather than wrapped.
+func parseNumber(s string) (int, error) {
+    total := 0
+    for _, term := range strings.Split(s, "+") {
+        term = strings.TrimSpace(term)
+        v, err := strconv.Atoi(term)
+        if err != nil {
+            f, ferr := strconv.ParseFloat(term, 64)
+            if ferr != nil || f != math.Trunc(f) {
+                return 0, fmt.Errorf("%w: invalid number %q", primefinder.ErrInvalidArgument, s)
+            }
+            // float64(math.MaxInt) rounds up to 2^63, which is already too big
+            if f >= float64(math.MaxInt) || f < float64(math.MinInt) {
+                return 0, fmt.Errorf("%w: number %q out of range", primefinder.ErrInvalidArgument, s)
+            }
+            v = int(f)
+        }
+        if (v > 0 && total > math.MaxInt-v) || (v < 0 && total < math.MinInt-v) {
+            return 0, fmt.Errorf("%w: number %q out of range", primefinder.ErrInvalidArgument, s)
+        }
+        total += v
+    }
+    return total, nil
+}
+
+// newUniformSampler returns a function drawing uniform integers in
+// [start, end], from crypto/rand or from a seeded PCG generator
+func newUniformSampler(start, end int, useCrypto bool, seed uint64) func() int {
+    width := uint64(end - start + 1)
+    if useCrypto {
+        limit := new(big.Int).SetUint64(width)
+        return func() int {
+            v, err := cryptorand.Int(cryptorand.Reader, limit)
+            if err != nil {
+                panic(err)
+            }
+            return start + int(v.Int64())
+        }
+    }
+    rng := rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15))
+    return func() int {
+        return start + int(rng.Uint64N(width))
+    }
+}
+
+// randomPrimes samples count random primes from [start, end], giving up on a
+// prime after maxTries candidates
+func randomPrimes(start, end, count, maxTries int, sample func() int) ([]int, error) {
+    primes := make([]int, 0, count)
+    for len(primes) < count {
+        found := false
+        for try := 0; try < maxTries; try++ {
+            if n := sample(); primefinder.IsProbablePrime(n) {
+                primes = append(primes, n)
+                found = true
+                break
+            }
+        }
+        if !found {
+            return primes, fmt.Errorf("no prime found in [%d, %d] after %d tries", start, end, maxTries)
+        }
+    }
+    return primes, nil
+}
+
+// runRandPrime implements `randprime`
+func runRandPrime(args []string) error {
+    fs := flag.NewFlagSet("randprime", flag.ExitOnError)
+    start := numberFlag(2)
+    end := numberFlag(1000000)
+    fs.Var(&start, "start", "Start of range (accepts 1e15 and 1e15+1e9 forms)")
+    fs.Var(&end, "end", "End of range (accepts 1e15 and 1e15+1e9 forms)")
+    count := fs.Int("count", 1, "Number of random primes to return")
+    maxTries := fs.Int("max-tries", 100000, "Candidates tried per prime before giving up")
+    useCrypto := fs.Bool("crypto", false, "Use crypto/rand instead of a seeded generator")
+    seed := fs.Uint64("seed", 0, "Seed for the generator (0 picks one from the clock)")
+    if err := parseFlags(fs, args); err != nil {
+        return err
+    }
+    
+    if start > end || *count < 1 {
+        return fmt.Errorf("%w: need start <= end and a positive count", primefinder.ErrInvalidRange)
+    }
+    if *seed == 0 && !*useCrypto {
+        *seed = uint64(time.Now().UnixNano())
+        fmt.Println(tr("# seed %d", *seed))
+    }
+    
+    sample := newUniformSampler(int(start), int(end), *useCrypto, *seed)
+    primes, err := randomPrimes(int(start), int(end), *count, *maxTries, sample)
+    for _, p := range primes {
+        fmt.Println(p)
+    }
+    return err
+}
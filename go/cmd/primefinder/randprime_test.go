@@ -0,0 +1,55 @@
+// randprime_test.go
+package main
+
+import (
+    "errors"
+    "testing"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+func TestParseNumber(t *testing.T) {
+    tests := []struct {
+        in       string
+        expected int
+    }{
+        {"100", 100},
+        {"1e6", 1000000},
+        {"1e15+1e9", 1000001000000000},
+        {"10+5", 15},
+    }
+    for _, tt := range tests {
+        if got, err := parseNumber(tt.in); err != nil || got != tt.expected {
+            t.Errorf("parseNumber(%q) = %d, %v; expected %d", tt.in, got, err, tt.expected)
+        }
+    }
+    for _, bad := range []string{"", "abc", "1.5", "1e19", "-1e19", "5e18+5e18", "-5e18+-5e18", "9223372036854775807+1"} {
+        if _, err := parseNumber(bad); !errors.Is(err, primefinder.ErrInvalidArgument) {
+            t.Errorf("parseNumber(%q): got %v, expected ErrInvalidArgument", bad, err)
+        }
+    }
+}
+
+func TestRandomPrimesSeeded(t *testing.T) {
+    start, end := 1000000000000000, 1000001000000000
+    first, err := randomPrimes(start, end, 5, 10000, newUniformSampler(start, end, false, 42))
+    if err != nil {
+        t.Fatalf("randomPrimes failed: %v", err)
+    }
+    again, _ := randomPrimes(start, end, 5, 10000, newUniformSampler(start, end, false, 42))
+    
+    for i, p := range first {
+        if p < start || p > end || !primefinder.IsProbablePrime(p) {
+            t.Errorf("Sampled %d is not a prime in range", p)
+        }
+        if again[i] != p {
+            t.Errorf("Same seed gave different primes: %v vs %v", first, again)
+            break
+        }
+    }
+    
+    // A range without primes gives up after maxTries
+    if _, err := randomPrimes(24, 28, 1, 50, newUniformSampler(24, 28, false, 1)); err == nil {
+        t.Error("Expected an error for a range without primes")
+    }
+}
@@ -0,0 +1,127 @@
+// ranges.go
+package main
+
+import (
+    "fmt"
+    "strings"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+// RangeResult is the outcome for one range of a -ranges run
+type RangeResult struct {
+    StartRange        int                          `json:"start_range"`
+    EndRange          int                          `json:"end_range"`
+    PrimesFound       int                          `json:"primes_found"`
+    ExecutionTime     float64                      `json:"execution_time_seconds"`
+    PrimesEmitted     int                          `json:"primes_emitted,omitempty"`
+    QuarantinedChunks [][2]int                     `json:"quarantined_chunks,omitempty"`
+    UnsearchedChunks  [][2]int                     `json:"unsearched_chunks,omitempty"`
+    WorkerUtilization []WorkerUtilization          `json:"worker_utilization,omitempty"`
+    WorkerCounts      []WorkerCount                `json:"worker_count_over_time,omitempty"`
+    DeadlineReached   bool                         `json:"deadline_reached,omitempty"`
+    CompletePrefix    *[2]int                      `json:"complete_prefix,omitempty"`
+    LimitReached      bool                         `json:"limit_reached,omitempty"`
+    KnownValueCheck   *primefinder.KnownValueCheck `json:"known_value_check,omitempty"`
+    Pairs             *PairReport                  `json:"pairs,omitempty"`
+    Gaps              *primefinder.GapReport       `json:"gaps,omitempty"`
+    Primes            []int                        `json:"primes,omitempty"`
+}
+
+// PairReport counts the prime pairs (p, p+gap) of one kind in a range
+type PairReport struct {
+    Kind  string   `json:"kind"`
+    Gap   int      `json:"gap"`
+    Count int      `json:"count"`
+    Pairs [][2]int `json:"pairs,omitempty"` // with -save-primes
+}
+
+// pairStage returns a report on the pairs of a kind and the pipeline stage
+// that fills it in from the ascending primes passing through, listing the
+// pairs if list is set
+func pairStage(kind string, list bool) (*PairReport, primefinder.Transform) {
+    finder := primefinder.NewPairFinder(primefinder.PairGaps[kind])
+    report := &PairReport{Kind: kind, Gap: finder.Gap}
+    return report, primefinder.Tap(finder, func(lower []int) {
+        report.Count += len(lower)
+        if list {
+            for _, p := range lower {
+                report.Pairs = append(report.Pairs, [2]int{p, p + finder.Gap})
+            }
+        }
+    })
+}
+
+// WorkerUtilization reports how busy one worker was
+type WorkerUtilization struct {
+    Worker      int     `json:"worker"`
+    Chunks      int     `json:"chunks"`
+    BusySeconds float64 `json:"busy_seconds"`
+    Utilization float64 `json:"utilization"`
+}
+
+// workerUtilization converts per-worker search statistics for the result
+func workerUtilization(stats []primefinder.WorkerStats) []WorkerUtilization {
+    var out []WorkerUtilization
+    for i, s := range stats {
+        out = append(out, WorkerUtilization{Worker: i, Chunks: s.Chunks, BusySeconds: s.Busy.Seconds(), Utilization: s.Utilization})
+    }
+    return out
+}
+
+// RangeSummary aggregates the ranges of a -ranges run
+type RangeSummary struct {
+    Ranges               int     `json:"ranges"`
+    PrimesFound          int     `json:"primes_found"`
+    PrimesEmitted        int     `json:"primes_emitted,omitempty"`
+    ExecutionTime        float64 `json:"execution_time_seconds"`
+    QuarantinedChunks    int     `json:"quarantined_chunks"`
+    UnsearchedChunks     int     `json:"unsearched_chunks,omitempty"`
+    PairsFound           int     `json:"pairs_found,omitempty"`
+    MaxGap               int     `json:"max_gap,omitempty"`
+    KnownValueMismatches int     `json:"known_value_mismatches"`
+}
+
+// parseRanges parses a comma-separated list of START..END ranges, where each
+// bound is written as for numberFlag
+func parseRanges(spec string) ([][2]int, error) {
+    var ranges [][2]int
+    for _, part := range strings.Split(spec, ",") {
+        lo, hi, ok := strings.Cut(strings.TrimSpace(part), "..")
+        if !ok {
+            return nil, fmt.Errorf("%w: range %q is not of the form START..END", primefinder.ErrInvalidArgument, part)
+        }
+        start, err := parseNumber(lo)
+        if err != nil {
+            return nil, err
+        }
+        end, err := parseNumber(hi)
+        if err != nil {
+            return nil, err
+        }
+        ranges = append(ranges, [2]int{start, end})
+    }
+    return ranges, nil
+}
+
+// summarizeRanges totals the per-range results
+func summarizeRanges(ranges []RangeResult) *RangeSummary {
+    summary := &RangeSummary{Ranges: len(ranges)}
+    for _, r := range ranges {
+        summary.PrimesFound += r.PrimesFound
+        summary.PrimesEmitted += r.PrimesEmitted
+        summary.ExecutionTime += r.ExecutionTime
+        summary.QuarantinedChunks += len(r.QuarantinedChunks)
+        summary.UnsearchedChunks += len(r.UnsearchedChunks)
+        if r.Pairs != nil {
+            summary.PairsFound += r.Pairs.Count
+        }
+        if r.Gaps != nil {
+            summary.MaxGap = max(summary.MaxGap, r.Gaps.MaxGap)
+        }
+        if r.KnownValueCheck != nil && !r.KnownValueCheck.Matched {
+            summary.KnownValueMismatches++
+        }
+    }
+    return summary
+}
@@ -0,0 +1,74 @@
+// ranges_test.go
+package main
+
+import (
+    "reflect"
+    "testing"
+    "time"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+func TestParseRanges(t *testing.T) {
+    tests := []struct {
+        spec     string
+        expected [][2]int
+        wantErr  bool
+    }{
+        {"1..100", [][2]int{{1, 100}}, false},
+        {"1..1e6, 1e9..1e9+1e5", [][2]int{{1, 1000000}, {1000000000, 1000100000}}, false},
+        {"1-100", nil, true},
+        {"1..x", nil, true},
+        {"", nil, true},
+    }
+    for _, tt := range tests {
+        got, err := parseRanges(tt.spec)
+        if (err != nil) != tt.wantErr || !reflect.DeepEqual(got, tt.expected) {
+            t.Errorf("parseRanges(%q) = %v, %v; expected %v", tt.spec, got, err, tt.expected)
+        }
+    }
+}
+
+func TestSummarizeRanges(t *testing.T) {
+    ranges := []RangeResult{
+        {PrimesFound: 168, ExecutionTime: 0.5, KnownValueCheck: &primefinder.KnownValueCheck{Expected: 168, Actual: 168, Matched: true}},
+        {PrimesFound: 10, ExecutionTime: 0.25, QuarantinedChunks: [][2]int{{1, 2}, {3, 4}}, Gaps: &primefinder.GapReport{MaxGap: 14}, Pairs: &PairReport{Count: 3}},
+        {PrimesFound: 2, ExecutionTime: 0.25, KnownValueCheck: &primefinder.KnownValueCheck{Expected: 4, Actual: 2}},
+        {UnsearchedChunks: [][2]int{{5, 9}}, Gaps: &primefinder.GapReport{MaxGap: 8}},
+    }
+    expected := &RangeSummary{Ranges: 4, PrimesFound: 180, ExecutionTime: 1, QuarantinedChunks: 2, UnsearchedChunks: 1, PairsFound: 3, MaxGap: 14, KnownValueMismatches: 1}
+    if got := summarizeRanges(ranges); !reflect.DeepEqual(got, expected) {
+        t.Errorf("summarizeRanges = %+v, expected %+v", got, expected)
+    }
+}
+
+func TestWorkerUtilization(t *testing.T) {
+    stats := []primefinder.WorkerStats{
+        {Chunks: 3, Busy: 1500 * time.Millisecond, Utilization: 0.75},
+        {Chunks: 1, Busy: 500 * time.Millisecond, Utilization: 0.25},
+    }
+    expected := []WorkerUtilization{
+        {Worker: 0, Chunks: 3, BusySeconds: 1.5, Utilization: 0.75},
+        {Worker: 1, Chunks: 1, BusySeconds: 0.5, Utilization: 0.25},
+    }
+    if got := workerUtilization(stats); !reflect.DeepEqual(got, expected) {
+        t.Errorf("workerUtilization = %+v, expected %+v", got, expected)
+    }
+    if got := workerUtilization(nil); got != nil {
+        t.Errorf("workerUtilization(nil) = %+v, expected nil", got)
+    }
+}
+
+func TestPairStage(t *testing.T) {
+    // The sexy pairs below 30, fed in two batches split between 11 and 13,
+    // pass every prime on
+    report, stage := pairStage("sexy", true)
+    pipeline := primefinder.Pipeline{stage}
+    out := append(pipeline.Apply([]int{2, 3, 5, 7, 11}), pipeline.Apply([]int{13, 17, 19, 23, 29})...)
+    out = append(out, pipeline.Flush()...)
+    
+    expected := [][2]int{{5, 11}, {7, 13}, {11, 17}, {13, 19}, {17, 23}, {23, 29}}
+    if report.Count != len(expected) || !reflect.DeepEqual(report.Pairs, expected) || len(out) != 10 {
+        t.Errorf("got %d pairs %v and %d primes passed on, expected %v and 10", report.Count, report.Pairs, len(out), expected)
+    }
+}
@@ -0,0 +1,151 @@
+// rare.go
+package main
+
+import (
+    "flag"
+    "fmt"
+    "math/big"
+    "math/bits"
+    "os"
+    "runtime"
+    "time"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+// RareSearchResult reports a scan for rare primes of one kind
+type RareSearchResult struct {
+    Kind          string  `json:"kind"`
+    StartRange    int     `json:"start_range"`
+    EndRange      int     `json:"end_range"`
+    PrimesTested  int     `json:"primes_tested"`
+    Found         []int   `json:"found"`
+    ExecutionTime float64 `json:"execution_time_seconds"`
+}
+
+// rareKinds maps each supported kind to its membership test for prime p
+var rareKinds = map[string]func(p int) bool{
+    "wieferich":  isWieferichPrime,
+    "wilson":     isWilsonPrime,
+    "wallsunsun": isWallSunSunPrime,
+}
+
+// isWieferichPrime reports whether 2^(p-1) = 1 (mod p^2)
+func isWieferichPrime(p int) bool {
+    if p == 2 {
+        return false
+    }
+    bp := big.NewInt(int64(p))
+    mod := new(big.Int).Mul(bp, bp)
+    r := new(big.Int).Exp(big.NewInt(2), big.NewInt(int64(p-1)), mod)
+    return r.Cmp(big.NewInt(1)) == 0
+}
+
+// mulMod returns a*b mod m without overflow
+func mulMod(a, b, m uint64) uint64 {
+    hi, lo := bits.Mul64(a, b)
+    _, rem := bits.Div64(hi%m, lo, m)
+    return rem
+}
+
+// isWilsonPrime reports whether (p-1)! = -1 (mod p^2). The factorial is
+// accumulated with 64-bit modular multiplication, so p must be below 2^32.
+func isWilsonPrime(p int) bool {
+    if p >= 1<<32 {
+        panic(fmt.Sprintf("wilson test needs p < 2^32, got %d", p))
+    }
+    mod := uint64(p) * uint64(p)
+    f := uint64(1)
+    for i := uint64(2); i < uint64(p); i++ {
+        f = mulMod(f, i, mod)
+    }
+    return f == mod-1
+}
+
+// fibMod returns F(n) mod m using fast doubling
+func fibMod(n uint64, m *big.Int) *big.Int {
+    a, b := big.NewInt(0), big.NewInt(1) // F(0), F(1)
+    t := new(big.Int)
+    for i := bits.Len64(n) - 1; i >= 0; i-- {
+        // F(2k) = F(k) * (2F(k+1) - F(k)); F(2k+1) = F(k)^2 + F(k+1)^2
+        t.Lsh(b, 1).Sub(t, a).Mul(t, a).Mod(t, m)
+        c := new(big.Int).Set(t)
+        t.Mul(a, a)
+        d := new(big.Int).Mul(b, b)
+        d.Add(d, t).Mod(d, m)
+        a, b = c, d
+        if n>>uint(i)&1 == 1 {
+            a, b = b, new(big.Int).Add(a, b)
+            b.Mod(b, m)
+        }
+    }
+    return a
+}
+
+// isWallSunSunPrime reports whether p^2 divides F(p - (p/5)), where (p/5) is
+// the Legendre symbol. No such prime is known.
+func isWallSunSunPrime(p int) bool {
+    if p == 5 {
+        return false
+    }
+    n := uint64(p)
+    switch p % 5 {
+    case 1, 4:
+        n--
+    default:
+        n++
+    }
+    bp := big.NewInt(int64(p))
+    return fibMod(n, new(big.Int).Mul(bp, bp)).Sign() == 0
+}
+
+// runRare implements `rare -kind wieferich`
+func runRare(args []string) error {
+    fs := flag.NewFlagSet("rare", flag.ExitOnError)
+    kind := fs.String("kind", "wieferich", "Prime kind: wieferich, wilson, or wallsunsun")
+    start := fs.Int("start", 1, "Start of range")
+    end := fs.Int("end", 100000, "End of range")
+    workers := fs.Int("workers", runtime.NumCPU(), "Number of workers")
+    output := fs.String("output", "", "Output JSON file (default stdout)")
+    if err := parseFlags(fs, args); err != nil {
+        return err
+    }
+    
+    member, ok := rareKinds[*kind]
+    if !ok {
+        return fmt.Errorf("%w: unknown kind %q", primefinder.ErrInvalidArgument, *kind)
+    }
+    if err := primefinder.ValidateRange(*start, *end, *workers); err != nil {
+        return err
+    }
+    if *kind == "wilson" && *end >= 1<<32 {
+        return fmt.Errorf("%w: wilson search is limited to end < 2^32", primefinder.ErrInvalidRange)
+    }
+    
+    startTime := time.Now()
+    primes, _ := primefinder.FindRangeConcurrent(*start, *end, *workers)
+    found := filterParallel(primes, *workers, member)
+    if found == nil {
+        found = []int{}
+    }
+    
+    result := RareSearchResult{
+        Kind:          *kind,
+        StartRange:    *start,
+        EndRange:      *end,
+        PrimesTested:  len(primes),
+        Found:         found,
+        ExecutionTime: time.Since(startTime).Seconds(),
+    }
+    
+    out := os.Stdout
+    if *output != "" {
+        file, err := os.Create(*output)
+        if err != nil {
+            return fmt.Errorf("%w: %v", primefinder.ErrSinkWrite, err)
+        }
+        defer file.Close()
+        out = file
+    }
+    return writeJSON(out, result, "")
+}
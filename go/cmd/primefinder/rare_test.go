@@ -0,0 +1,40 @@
+// rare_test.go
+package main
+
+import (
+    "math/big"
+    "reflect"
+    "testing"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+func TestRarePrimes(t *testing.T) {
+    tests := []struct {
+        kind     string
+        end      int
+        expected []int
+    }{
+        {"wieferich", 4000, []int{1093, 3511}},
+        {"wilson", 600, []int{5, 13, 563}},
+        {"wallsunsun", 3000, nil},
+    }
+    
+    for _, tt := range tests {
+        primes, _ := primefinder.FindRangeConcurrent(1, tt.end, 2)
+        got := filterParallel(primes, 2, rareKinds[tt.kind])
+        if !reflect.DeepEqual(got, tt.expected) {
+            t.Errorf("%s primes up to %d = %v, expected %v", tt.kind, tt.end, got, tt.expected)
+        }
+    }
+}
+
+func TestFibMod(t *testing.T) {
+    fib := []int64{0, 1, 1, 2, 3, 5, 8, 13, 21, 34, 55, 89, 144}
+    m := big.NewInt(1000)
+    for n, f := range fib {
+        if got := fibMod(uint64(n), m); got.Int64() != f%1000 {
+            t.Errorf("fibMod(%d) = %v, expected %d", n, got, f)
+        }
+    }
+}
@@ -0,0 +1,58 @@
+// runid.go
+package main
+
+import (
+    "crypto/rand"
+    "io"
+    "strings"
+    "time"
+)
+
+// crockford is the Crockford base32 alphabet used by ULIDs
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// newULID returns a ULID: a 48-bit millisecond timestamp followed by 80 bits
+// of entropy, encoded as 26 Crockford base32 characters. ULIDs sort
+// lexically in creation order.
+func newULID(t time.Time, entropy io.Reader) (string, error) {
+    var id [16]byte
+    ms := uint64(t.UnixMilli())
+    for i := 5; i >= 0; i-- {
+        id[i] = byte(ms)
+        ms >>= 8
+    }
+    if _, err := io.ReadFull(entropy, id[6:]); err != nil {
+        return "", err
+    }
+    
+    // 128 bits -> 26 characters of 5 bits each, the first holding only 3
+    var out [26]byte
+    var acc uint64
+    bits := 2 // pad to 130 bits so the first character is the top 3 bits
+    pos := 0
+    for _, b := range id {
+        acc = acc<<8 | uint64(b)
+        bits += 8
+        for bits >= 5 {
+            bits -= 5
+            out[pos] = crockford[(acc>>uint(bits))&31]
+            pos++
+        }
+    }
+    return string(out[:]), nil
+}
+
+// newRunID returns a fresh ULID identifying one run of the tool
+func newRunID() string {
+    id, err := newULID(time.Now(), rand.Reader)
+    if err != nil {
+        // crypto/rand does not fail on supported platforms
+        panic(err)
+    }
+    return id
+}
+
+// expandRunID substitutes the run ID for {run_id} in a file name
+func expandRunID(name, runID string) string {
+    return strings.ReplaceAll(name, "{run_id}", runID)
+}
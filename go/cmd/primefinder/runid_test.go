@@ -0,0 +1,31 @@
+// runid_test.go
+package main
+
+import (
+    "bytes"
+    "testing"
+    "time"
+)
+
+func TestNewULID(t *testing.T) {
+    zeros := bytes.NewReader(make([]byte, 10))
+    id, err := newULID(time.UnixMilli(0), zeros)
+    if err != nil {
+        t.Fatalf("newULID failed: %v", err)
+    }
+    if id != "00000000000000000000000000" {
+        t.Errorf("Zero ULID = %q", id)
+    }
+    
+    // Known timestamp encoding from the ULID spec: 1469918176385 ms -> 01ARYZ6S41
+    id, _ = newULID(time.UnixMilli(1469918176385), bytes.NewReader(make([]byte, 10)))
+    if id[:10] != "01ARYZ6S41" {
+        t.Errorf("Timestamp prefix = %q, expected 01ARYZ6S41", id[:10])
+    }
+    
+    earlier := newRunID()
+    time.Sleep(2 * time.Millisecond)
+    if later := newRunID(); later <= earlier || len(later) != 26 {
+        t.Errorf("Run IDs not increasing: %q then %q", earlier, later)
+    }
+}
@@ -0,0 +1,145 @@
+// selftest.go
+package main
+
+import (
+    "flag"
+    "fmt"
+    "sync"
+    "time"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+// selfTestCheck is one known-answer check run by the selftest subcommand
+type selfTestCheck struct {
+    name string
+    run  func() error
+}
+
+// simpleSieve returns primality of 0..limit using a plain Sieve of
+// Eratosthenes; it is deliberately independent of primefinder.IsPrime
+func simpleSieve(limit int) []bool {
+    prime := make([]bool, limit+1)
+    for i := 2; i <= limit; i++ {
+        prime[i] = true
+    }
+    for i := 2; i*i <= limit; i++ {
+        if prime[i] {
+            for j := i * i; j <= limit; j += i {
+                prime[j] = false
+            }
+        }
+    }
+    return prime
+}
+
+var selfTestChecks = []selfTestCheck{
+    {"prime counts pi(10^k) for k=1..6", func() error {
+        limit := 1
+        for k := 1; k <= 6; k++ {
+            limit *= 10
+            if got := len(primefinder.FindRange(1, limit)); int64(got) != primefinder.KnownPi[k] {
+                return fmt.Errorf("pi(10^%d) = %d, expected %d", k, got, primefinder.KnownPi[k])
+            }
+        }
+        return nil
+    }},
+    {"known primes", func() error {
+        for _, n := range []int{2, 3, 5, 7919, 104729, 1000003, 2147483647} {
+            if !primefinder.IsPrime(n) {
+                return fmt.Errorf("%d reported composite", n)
+            }
+        }
+        return nil
+    }},
+    {"known composites", func() error {
+        // Carmichael numbers, prime squares, and products of close primes
+        for _, n := range []int{0, 1, 4, 561, 1105, 1729, 7921, 1000001, 2147483649} {
+            if primefinder.IsPrime(n) {
+                return fmt.Errorf("%d reported prime", n)
+            }
+        }
+        return nil
+    }},
+    {"trial division agrees with sieve up to 10^5", func() error {
+        sieve := simpleSieve(100000)
+        for n, want := range sieve {
+            if primefinder.IsPrime(n) != want {
+                return fmt.Errorf("IsPrime(%d) = %v, sieve says %v", n, !want, want)
+            }
+        }
+        return nil
+    }},
+    {"segmented sieve agrees with trial division up to 10^6", func() error {
+        sieved, trial := primefinder.FindRangeSieve(1, 1000000), primefinder.FindRange(1, 1000000)
+        if len(sieved) != len(trial) {
+            return fmt.Errorf("sieve found %d primes, trial division %d", len(sieved), len(trial))
+        }
+        for i := range sieved {
+            if sieved[i] != trial[i] {
+                return fmt.Errorf("prime[%d]: sieve %d, trial division %d", i, sieved[i], trial[i])
+            }
+        }
+        return nil
+    }},
+    {"concurrent matches sequential", func() error {
+        expected := primefinder.FindRange(1, 50000)
+        for _, workers := range []int{1, 2, 3, 7, 16, 64} {
+            primes, _ := primefinder.FindRangeConcurrent(1, 50000, workers)
+            if len(primes) != len(expected) {
+                return fmt.Errorf("%d workers found %d primes, expected %d", workers, len(primes), len(expected))
+            }
+            for i := range primes {
+                if primes[i] != expected[i] {
+                    return fmt.Errorf("%d workers: prime[%d] = %d, expected %d", workers, i, primes[i], expected[i])
+                }
+            }
+        }
+        return nil
+    }},
+    {"concurrency stress", func() error {
+        var wg sync.WaitGroup
+        errs := make(chan error, 32)
+        for i := 0; i < 32; i++ {
+            wg.Add(1)
+            go func(workers int) {
+                defer wg.Done()
+                if primes, _ := primefinder.FindRangeConcurrent(1, 10000, workers); len(primes) != 1229 {
+                    errs <- fmt.Errorf("%d workers found %d primes below 10^4, expected 1229", workers, len(primes))
+                }
+            }(i%8 + 1)
+        }
+        wg.Wait()
+        close(errs)
+        return <-errs
+    }},
+}
+
+// runSelfTest implements `selftest`, printing pass/fail for every check
+func runSelfTest(args []string) error {
+    fs := flag.NewFlagSet("selftest", flag.ExitOnError)
+    if err := parseFlags(fs, args); err != nil {
+        return err
+    }
+    
+    failed := 0
+    for _, check := range selfTestChecks {
+        startTime := time.Now()
+        err := check.run()
+        status := tr("PASS")
+        if err != nil {
+            status = tr("FAIL")
+            failed++
+        }
+        fmt.Printf("[%s] %s (%v)\n", status, check.name, time.Since(startTime).Round(time.Millisecond))
+        if err != nil {
+            fmt.Printf("       %v\n", err)
+        }
+    }
+    
+    if failed > 0 {
+        return fmt.Errorf("%d of %d self-test checks failed", failed, len(selfTestChecks))
+    }
+    fmt.Println(tr("All %d self-test checks passed", len(selfTestChecks)))
+    return nil
+}
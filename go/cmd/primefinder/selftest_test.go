@@ -0,0 +1,12 @@
+// selftest_test.go
+package main
+
+import "testing"
+
+func TestSelfTestChecksPass(t *testing.T) {
+    for _, check := range selfTestChecks {
+        if err := check.run(); err != nil {
+            t.Errorf("%s: %v", check.name, err)
+        }
+    }
+}
@@ -0,0 +1,257 @@
+// serve.go
+package main
+
+import (
+    "bufio"
+    "encoding/json"
+    "flag"
+    "fmt"
+    "net/http"
+    "os"
+    "path/filepath"
+    "slices"
+    "sort"
+    "strconv"
+    "time"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+// primeIndex answers queries from the primes of completed runs. Queries are
+// answered only inside covered, the ranges the loaded results searched in
+// full; no number is ever tested.
+type primeIndex struct {
+    covered [][2]int // ascending, disjoint
+    primes  []int    // ascending, without duplicates
+}
+
+// add records a fully searched range and its primes; finish must be called
+// once everything is added
+func (x *primeIndex) add(r RangeResult) {
+    x.covered = append(x.covered, [2]int{r.StartRange, r.EndRange})
+    x.primes = append(x.primes, r.Primes...)
+}
+
+// finish sorts the primes and joins overlapping ranges, as loaded results
+// may overlap and descending ones list primes from the top
+func (x *primeIndex) finish() {
+    slices.Sort(x.primes)
+    x.primes = slices.Compact(x.primes)
+    x.covered = mergeRanges(x.covered)
+}
+
+// covers reports whether [lo, hi] lies inside one covered range
+func (x *primeIndex) covers(lo, hi int) bool {
+    i := sort.Search(len(x.covered), func(i int) bool { return x.covered[i][1] >= lo })
+    return i < len(x.covered) && x.covered[i][0] <= lo && hi <= x.covered[i][1]
+}
+
+// between returns the primes in [lo, hi]
+func (x *primeIndex) between(lo, hi int) []int {
+    i, _ := slices.BinarySearch(x.primes, lo)
+    j, _ := slices.BinarySearch(x.primes, hi+1)
+    return x.primes[i:j]
+}
+
+// nth returns the k-th prime, counting from 2, if the covered range holding
+// 2 reaches it
+func (x *primeIndex) nth(k int) (int, bool) {
+    if k < 1 || len(x.covered) == 0 || x.covered[0][0] > 2 {
+        return 0, false
+    }
+    prefix := x.between(2, x.covered[0][1])
+    if k > len(prefix) {
+        return 0, false
+    }
+    return prefix[k-1], true
+}
+
+// loadArtifacts builds an index from the result files in dir: files written
+// with -format=bin, and JSON results saved with -save-primes. Results that
+// did not search their range in full, or kept only some primes, are skipped
+// with a warning, as are binary files too old to record that.
+func loadArtifacts(dir string) (*primeIndex, error) {
+    entries, err := os.ReadDir(dir)
+    if err != nil {
+        return nil, fmt.Errorf("%w: -static: %v", primefinder.ErrInvalidArgument, err)
+    }
+    index := &primeIndex{}
+    for _, entry := range entries {
+        path := filepath.Join(dir, entry.Name())
+        switch filepath.Ext(path) {
+        case ".bin":
+            file, err := os.Open(path)
+            if err != nil {
+                return nil, err
+            }
+            ranges, reason, err := readBinaryPrimes(file)
+            file.Close()
+            if err != nil {
+                return nil, fmt.Errorf("%s: %v", path, err)
+            }
+            if reason != "" {
+                fmt.Fprintln(os.Stderr, tr("Skipping %s: %s", path, reason))
+                continue
+            }
+            for _, r := range ranges {
+                index.add(r)
+            }
+        case ".json":
+            result, err := loadResult(path)
+            if err != nil {
+                return nil, err
+            }
+            if reason := incompleteReason(result); reason != "" {
+                fmt.Fprintln(os.Stderr, tr("Skipping %s: %s", path, reason))
+                continue
+            }
+            for _, r := range primeRanges(*result) {
+                index.add(r)
+            }
+        }
+    }
+    index.finish()
+    if len(index.covered) == 0 {
+        return nil, fmt.Errorf("%w: no complete results with primes in %s", primefinder.ErrInvalidArgument, dir)
+    }
+    return index, nil
+}
+
+// incompleteReason says why a result cannot answer queries about its range,
+// or returns "" if it can
+func incompleteReason(r *Result) string {
+    switch {
+    case r.RunID == "":
+        return "not a search result"
+    case r.PrimesFound > 0 && len(r.Primes) == 0 && len(r.Ranges) == 0:
+        return "primes not saved (rerun with -save-primes)"
+    case r.Cancelled || r.DeadlineReached || r.LimitReached || len(r.UnsearchedChunks) > 0 || len(r.QuarantinedChunks) > 0:
+        return "range not searched in full"
+    case r.Stride > 1 || r.Transforms != "":
+        return "only some primes kept (-stride or -transform)"
+    }
+    for _, rr := range r.Ranges {
+        if rr.PrimesFound > 0 && len(rr.Primes) == 0 {
+            return "primes not saved (rerun with -save-primes)"
+        }
+        if rr.DeadlineReached || rr.LimitReached || len(rr.UnsearchedChunks) > 0 || len(rr.QuarantinedChunks) > 0 {
+            return "range not searched in full"
+        }
+    }
+    return ""
+}
+
+// handler returns the query endpoints:
+//
+//	GET /contains?n=N       whether N is prime
+//	GET /count?lo=A&hi=B    number of primes in [A, B]
+//	GET /select?k=K         the K-th prime
+//	GET /stream?lo=A&hi=B   the primes in [A, B], one per line
+//	GET /coverage           the ranges queries can ask about
+//
+// Queries outside the covered ranges get 404.
+func (x *primeIndex) handler() http.Handler {
+    mux := http.NewServeMux()
+    mux.HandleFunc("GET /coverage", func(w http.ResponseWriter, r *http.Request) {
+        writeQueryJSON(w, map[string]interface{}{"covered": x.covered, "primes": len(x.primes)})
+    })
+    mux.HandleFunc("GET /contains", func(w http.ResponseWriter, r *http.Request) {
+        n, ok := queryInts(w, r, "n")
+        if !ok || !x.coveredOr404(w, n[0], n[0]) {
+            return
+        }
+        _, prime := slices.BinarySearch(x.primes, n[0])
+        writeQueryJSON(w, map[string]interface{}{"n": n[0], "prime": prime})
+    })
+    mux.HandleFunc("GET /count", func(w http.ResponseWriter, r *http.Request) {
+        b, ok := queryInts(w, r, "lo", "hi")
+        if !ok || !x.coveredOr404(w, b[0], b[1]) {
+            return
+        }
+        writeQueryJSON(w, map[string]interface{}{"lo": b[0], "hi": b[1], "count": len(x.between(b[0], b[1]))})
+    })
+    mux.HandleFunc("GET /select", func(w http.ResponseWriter, r *http.Request) {
+        k, ok := queryInts(w, r, "k")
+        if !ok {
+            return
+        }
+        p, found := x.nth(k[0])
+        if !found {
+            http.Error(w, fmt.Sprintf("prime %d is not within a covered range starting at 2", k[0]), http.StatusNotFound)
+            return
+        }
+        writeQueryJSON(w, map[string]interface{}{"k": k[0], "prime": p})
+    })
+    mux.HandleFunc("GET /stream", func(w http.ResponseWriter, r *http.Request) {
+        b, ok := queryInts(w, r, "lo", "hi")
+        if !ok || !x.coveredOr404(w, b[0], b[1]) {
+            return
+        }
+        w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+        out := bufio.NewWriter(w)
+        buf := make([]byte, 0, 24)
+        for _, p := range x.between(b[0], b[1]) {
+            buf = strconv.AppendInt(buf[:0], int64(p), 10)
+            out.Write(append(buf, '\n'))
+        }
+        out.Flush()
+    })
+    return mux
+}
+
+// coveredOr404 reports whether [lo, hi] is covered, answering 404 if not
+func (x *primeIndex) coveredOr404(w http.ResponseWriter, lo, hi int) bool {
+    if lo > hi {
+        http.Error(w, fmt.Sprintf("empty range [%d, %d]", lo, hi), http.StatusBadRequest)
+        return false
+    }
+    if !x.covers(lo, hi) {
+        http.Error(w, fmt.Sprintf("[%d, %d] is not covered by the loaded results", lo, hi), http.StatusNotFound)
+        return false
+    }
+    return true
+}
+
+// queryInts parses the named integer query parameters, answering 400 if
+// one is missing or malformed
+func queryInts(w http.ResponseWriter, r *http.Request, names ...string) ([]int, bool) {
+    values := make([]int, len(names))
+    for i, name := range names {
+        raw := r.URL.Query().Get(name)
+        v, err := parseNumber(raw)
+        if raw == "" || err != nil {
+            http.Error(w, fmt.Sprintf("parameter %s must be an integer", name), http.StatusBadRequest)
+            return nil, false
+        }
+        values[i] = v
+    }
+    return values, true
+}
+
+// writeQueryJSON writes v as a JSON response
+func writeQueryJSON(w http.ResponseWriter, v interface{}) {
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(v)
+}
+
+// runServe implements `serve -static DIR`: a read-only query server over the
+// results of completed runs
+func runServe(args []string) error {
+    fs := flag.NewFlagSet("serve", flag.ExitOnError)
+    static := fs.String("static", "", "Directory of completed results (-format=bin files, or JSON saved with -save-primes) to answer queries from")
+    addr := fs.String("addr", ":8080", "Address to listen on")
+    if err := parseFlags(fs, args); err != nil {
+        return err
+    }
+    
+    if *static == "" {
+        return fmt.Errorf("%w: serve needs -static; queries are only answered from completed results", primefinder.ErrInvalidArgument)
+    }
+    index, err := loadArtifacts(*static)
+    if err != nil {
+        return err
+    }
+    fmt.Println(tr("Serving %d primes in %d ranges from %s on %s", len(index.primes), len(index.covered), *static, *addr))
+    server := &http.Server{Addr: *addr, Handler: index.handler(), ReadHeaderTimeout: 10 * time.Second}
+    return server.ListenAndServe()
+}
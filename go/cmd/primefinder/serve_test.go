@@ -0,0 +1,84 @@
+// serve_test.go
+package main
+
+import (
+    "net/http"
+    "net/http/httptest"
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+)
+
+func TestPrimeIndexQueries(t *testing.T) {
+    index := &primeIndex{}
+    index.add(RangeResult{StartRange: 1, EndRange: 30, Primes: []int{2, 3, 5, 7, 11, 13, 17, 19, 23, 29}})
+    index.add(RangeResult{StartRange: 25, EndRange: 50, Primes: []int{47, 43, 41, 37, 31, 29}}) // descending, overlapping
+    index.add(RangeResult{StartRange: 100, EndRange: 110, Primes: []int{101, 103, 107, 109}})
+    index.finish()
+    
+    tests := []struct {
+        query    string
+        status   int
+        expected string
+    }{
+        {"/contains?n=29", http.StatusOK, `{"n":29,"prime":true}`},
+        {"/contains?n=49", http.StatusOK, `{"n":49,"prime":false}`},
+        {"/contains?n=60", http.StatusNotFound, "not covered"},
+        {"/count?lo=1&hi=50", http.StatusOK, `"count":15`},
+        {"/count?lo=100&hi=110", http.StatusOK, `"count":4`},
+        {"/count?lo=40&hi=100", http.StatusNotFound, "not covered"},
+        {"/count?lo=5", http.StatusBadRequest, "hi must be an integer"},
+        {"/select?k=15", http.StatusOK, `{"k":15,"prime":47}`},
+        {"/select?k=16", http.StatusNotFound, "not within a covered range"},
+        {"/stream?lo=20&hi=32", http.StatusOK, "23\n29\n31\n"},
+        {"/coverage", http.StatusOK, `{"covered":[[1,50],[100,110]],"primes":19}`},
+    }
+    handler := index.handler()
+    for _, tt := range tests {
+        rec := httptest.NewRecorder()
+        handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.query, nil))
+        if rec.Code != tt.status || !strings.Contains(rec.Body.String(), tt.expected) {
+            t.Errorf("GET %s = %d %q, expected %d containing %q", tt.query, rec.Code, rec.Body.String(), tt.status, tt.expected)
+        }
+    }
+    
+    // The server is read-only
+    rec := httptest.NewRecorder()
+    handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/count?lo=1&hi=10", nil))
+    if rec.Code != http.StatusMethodNotAllowed {
+        t.Errorf("POST /count = %d, expected 405", rec.Code)
+    }
+}
+
+func TestLoadArtifacts(t *testing.T) {
+    dir := t.TempDir()
+    write := func(name string, writer OutputWriter, result Result) {
+        file, err := os.Create(filepath.Join(dir, name))
+        if err != nil {
+            t.Fatal(err)
+        }
+        defer file.Close()
+        if err := writer.WriteResult(file, result); err != nil {
+            t.Fatal(err)
+        }
+    }
+    write("a.bin", binaryWriter{}, Result{RunID: "a", StartRange: 1, EndRange: 10, PrimesFound: 4, Primes: []int{2, 3, 5, 7}})
+    write("strided.bin", binaryWriter{}, Result{RunID: "e", StartRange: 30, EndRange: 100, Stride: 4, Offset: 3, PrimesFound: 2, Primes: []int{31, 43}})
+    write("limited.bin", binaryWriter{}, Result{RunID: "f", StartRange: 100, EndRange: 1000, LimitReached: true, PrimesFound: 1, Primes: []int{101}})
+    write("b.json", jsonWriter{}, Result{RunID: "b", StartRange: 11, EndRange: 20, PrimesFound: 4, Primes: []int{11, 13, 17, 19}})
+    write("partial.json", jsonWriter{}, Result{RunID: "c", StartRange: 21, EndRange: 1000, PrimesFound: 2, Primes: []int{23, 29}, Cancelled: true})
+    write("counts.json", jsonWriter{}, Result{RunID: "d", StartRange: 1000, EndRange: 2000, PrimesFound: 135})
+    
+    index, err := loadArtifacts(dir)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(index.covered) != 1 || index.covered[0] != [2]int{1, 20} || len(index.primes) != 8 {
+        t.Errorf("Loaded coverage %v with %d primes, expected [1, 20] with 8", index.covered, len(index.primes))
+    }
+    
+    if _, err := loadArtifacts(t.TempDir()); err == nil {
+        t.Error("Expected an error for a directory without results")
+    }
+}
@@ -0,0 +1,292 @@
+// simulate.go
+package main
+
+import (
+    "container/heap"
+    "encoding/csv"
+    "flag"
+    "fmt"
+    "io"
+    "math"
+    "math/rand/v2"
+    "os"
+    "sort"
+    "strconv"
+    "text/tabwriter"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+// writeChunkCosts records chunk costs as CSV for later replay by simulate
+func writeChunkCosts(w io.Writer, costs []primefinder.ChunkCost) error {
+    out := csv.NewWriter(w)
+    out.Write([]string{"start", "end", "seconds"})
+    for _, c := range costs {
+        out.Write([]string{
+            strconv.Itoa(c.Start),
+            strconv.Itoa(c.End),
+            strconv.FormatFloat(c.Seconds, 'g', -1, 64),
+        })
+    }
+    out.Flush()
+    return out.Error()
+}
+
+// readChunkCosts reads costs written by writeChunkCosts
+func readChunkCosts(r io.Reader) ([]primefinder.ChunkCost, error) {
+    records, err := csv.NewReader(r).ReadAll()
+    if err != nil {
+        return nil, err
+    }
+    var costs []primefinder.ChunkCost
+    for i, record := range records {
+        if i == 0 || len(record) != 3 {
+            continue
+        }
+        start, err1 := strconv.Atoi(record[0])
+        end, err2 := strconv.Atoi(record[1])
+        seconds, err3 := strconv.ParseFloat(record[2], 64)
+        if err1 != nil || err2 != nil || err3 != nil {
+            return nil, fmt.Errorf("line %d: malformed cost record", i+1)
+        }
+        costs = append(costs, primefinder.ChunkCost{Start: start, End: end, Seconds: seconds})
+    }
+    return costs, nil
+}
+
+// modelChunkCosts splits [start, end] into chunks and assigns each the
+// trial-division cost model: time per candidate proportional to sqrt(n)
+func modelChunkCosts(start, end, chunks int) []primefinder.ChunkCost {
+    width := (end - start + 1) / chunks
+    if width < 1 {
+        width = 1
+    }
+    var costs []primefinder.ChunkCost
+    for lo := start; lo <= end; lo += width {
+        hi := lo + width - 1
+        if hi > end {
+            hi = end
+        }
+        // Integral of sqrt(x) over the chunk, in arbitrary units
+        cost := (math.Pow(float64(hi)+1, 1.5) - math.Pow(float64(lo), 1.5)) * 2 / 3 * 1e-9
+        costs = append(costs, primefinder.ChunkCost{Start: lo, End: hi, Seconds: cost})
+    }
+    return costs
+}
+
+// simulation holds one scheduling experiment. Each execution of a chunk
+// takes its cost scaled by a random factor of 1 + jitter*|N(0,1)| so that
+// stragglers occur; the same seed gives the same draws for every policy.
+type simulation struct {
+    costs     []primefinder.ChunkCost
+    workers   int
+    jitter    float64
+    stealCost float64
+    seed      uint64
+}
+
+// durations draws the actual execution time of every chunk, plus a second
+// independent draw used when a chunk is executed speculatively
+func (s simulation) durations() (first, backup []float64) {
+    rng := rand.New(rand.NewPCG(s.seed, s.seed+1))
+    first = make([]float64, len(s.costs))
+    backup = make([]float64, len(s.costs))
+    for i, c := range s.costs {
+        first[i] = c.Seconds * (1 + s.jitter*math.Abs(rng.NormFloat64()))
+        backup[i] = c.Seconds * (1 + s.jitter*math.Abs(rng.NormFloat64()))
+    }
+    return first, backup
+}
+
+// freeHeap is a min-heap of worker free times
+type freeHeap []float64
+
+func (h freeHeap) Len() int            { return len(h) }
+func (h freeHeap) Less(i, j int) bool  { return h[i] < h[j] }
+func (h freeHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *freeHeap) Push(x interface{}) { *h = append(*h, x.(float64)) }
+func (h *freeHeap) Pop() interface{} {
+    old := *h
+    item := old[len(old)-1]
+    *h = old[:len(old)-1]
+    return item
+}
+
+// staticMakespan gives each worker one contiguous block of chunks up front
+func (s simulation) staticMakespan() float64 {
+    d, _ := s.durations()
+    makespan := 0.0
+    n := len(d)
+    for w := 0; w < s.workers; w++ {
+        busy := 0.0
+        for i := w * n / s.workers; i < (w+1)*n/s.workers; i++ {
+            busy += d[i]
+        }
+        makespan = math.Max(makespan, busy)
+    }
+    return makespan
+}
+
+// dynamicMakespan hands chunks out in order to whichever worker frees up
+// first (a shared queue)
+func (s simulation) dynamicMakespan() float64 {
+    d, _ := s.durations()
+    free := make(freeHeap, s.workers)
+    makespan := 0.0
+    for _, cost := range d {
+        t := heap.Pop(&free).(float64) + cost
+        makespan = math.Max(makespan, t)
+        heap.Push(&free, t)
+    }
+    return makespan
+}
+
+// workStealingMakespan starts from the static blocks; a worker that runs out
+// of work steals the back half of the largest remaining queue, paying
+// stealCost per steal
+func (s simulation) workStealingMakespan() float64 {
+    d, _ := s.durations()
+    n := len(d)
+    queues := make([][]int, s.workers)
+    for w := range queues {
+        for i := w * n / s.workers; i < (w+1)*n/s.workers; i++ {
+            queues[w] = append(queues[w], i)
+        }
+    }
+    
+    clock := make([]float64, s.workers)
+    makespan := 0.0
+    for {
+        // Advance the worker that is free earliest
+        w := 0
+        for i := range clock {
+            if clock[i] < clock[w] {
+                w = i
+            }
+        }
+        if len(queues[w]) == 0 {
+            victim := -1
+            for i := range queues {
+                if len(queues[i]) > 1 && (victim < 0 || len(queues[i]) > len(queues[victim])) {
+                    victim = i
+                }
+            }
+            if victim < 0 {
+                // Nothing left to steal; finish whatever single chunks remain
+                clock[w] = math.Inf(1)
+                done := true
+                for i := range queues {
+                    if len(queues[i]) > 0 {
+                        done = false
+                    }
+                }
+                if done {
+                    break
+                }
+                continue
+            }
+            half := len(queues[victim]) / 2
+            queues[w] = append(queues[w], queues[victim][len(queues[victim])-half:]...)
+            queues[victim] = queues[victim][:len(queues[victim])-half]
+            clock[w] += s.stealCost
+        }
+        next := queues[w][0]
+        queues[w] = queues[w][1:]
+        clock[w] += d[next]
+        makespan = math.Max(makespan, clock[w])
+    }
+    return makespan
+}
+
+// speculativeMakespan runs the shared queue; once it is empty, idle workers
+// start backup copies of the running chunks expected to finish last, and a
+// chunk completes when either copy does
+func (s simulation) speculativeMakespan() float64 {
+    d, backup := s.durations()
+    type running struct {
+        chunk  int
+        finish float64
+    }
+    free := make(freeHeap, s.workers)
+    var inFlight []running
+    for i, cost := range d {
+        t := heap.Pop(&free).(float64)
+        inFlight = append(inFlight, running{i, t + cost})
+        heap.Push(&free, t+cost)
+    }
+    
+    // Idle workers in order of availability back up the latest finishers
+    sort.Slice(inFlight, func(i, j int) bool { return inFlight[i].finish > inFlight[j].finish })
+    idle := []float64(free)
+    sort.Float64s(idle)
+    makespan := 0.0
+    for i, r := range inFlight {
+        finish := r.finish
+        if i < len(idle) && idle[i] < r.finish {
+            finish = math.Min(finish, idle[i]+backup[r.chunk])
+        }
+        makespan = math.Max(makespan, finish)
+    }
+    return makespan
+}
+
+// runSimulate implements `simulate`
+func runSimulate(args []string) error {
+    fs := flag.NewFlagSet("simulate", flag.ExitOnError)
+    costsFile := fs.String("costs", "", "Chunk cost CSV recorded with -record-costs (default: sqrt cost model)")
+    start := fs.Int("start", 1, "Start of range for the cost model")
+    end := fs.Int("end", 100000000, "End of range for the cost model")
+    chunks := fs.Int("chunks", 256, "Number of chunks for the cost model")
+    workers := fs.Int("workers", 8, "Simulated workers")
+    jitter := fs.Float64("jitter", 0.1, "Per-execution cost noise (0 for deterministic costs)")
+    stealCost := fs.Float64("steal-cost", 0, "Seconds charged per steal in the work-stealing policy")
+    seed := fs.Uint64("seed", 1, "Seed for cost noise")
+    if err := parseFlags(fs, args); err != nil {
+        return err
+    }
+    
+    if *workers < 1 || *chunks < 1 || *jitter < 0 {
+        return fmt.Errorf("%w: workers and chunks must be positive and jitter non-negative", primefinder.ErrInvalidArgument)
+    }
+    
+    var costs []primefinder.ChunkCost
+    if *costsFile != "" {
+        file, err := os.Open(*costsFile)
+        if err != nil {
+            return err
+        }
+        defer file.Close()
+        if costs, err = readChunkCosts(file); err != nil {
+            return fmt.Errorf("%w: %s: %v", primefinder.ErrInvalidArgument, *costsFile, err)
+        }
+    } else {
+        if err := primefinder.ValidateRange(*start, *end, *workers); err != nil {
+            return err
+        }
+        costs = modelChunkCosts(*start, *end, *chunks)
+    }
+    
+    sim := simulation{costs: costs, workers: *workers, jitter: *jitter, stealCost: *stealCost, seed: *seed}
+    total := 0.0
+    first, _ := sim.durations()
+    for _, d := range first {
+        total += d
+    }
+    
+    fmt.Println(tr("Simulating %d chunks on %d workers (total work %.4gs)", len(costs), *workers, total))
+    w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', tabwriter.AlignRight)
+    fmt.Fprintln(w, "policy\tmakespan (s)\tefficiency\t")
+    for _, policy := range []struct {
+        name     string
+        makespan func() float64
+    }{
+        {"static", sim.staticMakespan},
+        {"dynamic", sim.dynamicMakespan},
+        {"work-stealing", sim.workStealingMakespan},
+        {"speculative", sim.speculativeMakespan},
+    } {
+        m := policy.makespan()
+        fmt.Fprintf(w, "%s\t%.4g\t%.1f%%\t\n", policy.name, m, 100*total/(float64(*workers)*m))
+    }
+    return w.Flush()
+}
@@ -0,0 +1,57 @@
+// simulate_test.go
+package main
+
+import (
+    "bytes"
+    "math"
+    "reflect"
+    "testing"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+func TestSimulationPolicies(t *testing.T) {
+    costs := []primefinder.ChunkCost{{Seconds: 1}, {Seconds: 1}, {Seconds: 1}, {Seconds: 5}, {Seconds: 1}, {Seconds: 1}}
+    sim := simulation{costs: costs, workers: 2}
+    
+    tests := []struct {
+        name     string
+        makespan float64
+    }{
+        // Blocks {1,1,1} and {5,1,1}
+        {"static", sim.staticMakespan()},
+        // Chunks handed out in order: the 5 starts at t=1 and the other
+        // worker takes everything else
+        {"dynamic", sim.dynamicMakespan()},
+        // The first worker finishes at t=3 and steals one of the two chunks
+        // queued behind the 5
+        {"work-stealing", sim.workStealingMakespan()},
+    }
+    expected := map[string]float64{"static": 7, "dynamic": 6, "work-stealing": 6}
+    for _, tt := range tests {
+        if math.Abs(tt.makespan-expected[tt.name]) > 1e-9 {
+            t.Errorf("%s makespan = %v, expected %v", tt.name, tt.makespan, expected[tt.name])
+        }
+    }
+    
+    // Without jitter a backup copy never finishes sooner
+    if m := sim.speculativeMakespan(); m != sim.dynamicMakespan() {
+        t.Errorf("speculative makespan = %v, expected %v", m, sim.dynamicMakespan())
+    }
+}
+
+func TestChunkCostsRoundTrip(t *testing.T) {
+    costs := modelChunkCosts(1, 1000, 4)
+    if len(costs) != 4 || costs[3].Seconds <= costs[0].Seconds {
+        t.Fatalf("Unexpected modelled costs: %+v", costs)
+    }
+    
+    var buf bytes.Buffer
+    if err := writeChunkCosts(&buf, costs); err != nil {
+        t.Fatal(err)
+    }
+    got, err := readChunkCosts(&buf)
+    if err != nil || !reflect.DeepEqual(got, costs) {
+        t.Errorf("Round trip gave %+v, %v; expected %+v", got, err, costs)
+    }
+}
@@ -0,0 +1,77 @@
+// stream.go
+package main
+
+import (
+    "bufio"
+    "context"
+    "errors"
+    "fmt"
+    "io"
+    "os"
+    "os/signal"
+    "strconv"
+    "syscall"
+    "time"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+// streamPrimes writes the primes of a streaming search to w, one per line,
+// and returns how many were written. A pairGap above zero means the primes
+// are the lower members of pairs found by a PairFinder in cfg.Pipeline, and
+// they are written as "p q" lines. Cancellation is not an error here; the
+// caller decides how to report it.
+func streamPrimes(ctx context.Context, w io.Writer, start, end, workers int, cfg primefinder.Config, pairGap int) (int, error) {
+    out := bufio.NewWriter(w)
+    primes, errs := primefinder.FindRangeStreamConfig(ctx, start, end, workers, cfg)
+    
+    count := 0
+    var writeErr error
+    buf := make([]byte, 0, 24)
+    for p := range primes {
+        if writeErr != nil {
+            continue // drain so the search can finish
+        }
+        buf = strconv.AppendInt(buf[:0], int64(p), 10)
+        if pairGap > 0 {
+            buf = append(buf, ' ')
+            buf = strconv.AppendInt(buf, int64(p+pairGap), 10)
+        }
+        buf = append(buf, '\n')
+        if _, writeErr = out.Write(buf); writeErr == nil {
+            count++
+        }
+    }
+    if writeErr == nil {
+        writeErr = out.Flush()
+    }
+    if writeErr != nil {
+        return count, fmt.Errorf("%w: %v", primefinder.ErrSinkWrite, writeErr)
+    }
+    return count, <-errs
+}
+
+// runStream implements -stream: primes, or with pairKind the pairs of that
+// kind, go to stdout as they are found and status messages to stderr.
+// SIGINT or SIGTERM stops the search.
+func runStream(start, end, workers int, cfg primefinder.Config, pairKind string) error {
+    ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+    defer stop()
+    
+    pairGap := 0
+    if pairKind != "" {
+        pairGap = primefinder.PairGaps[pairKind]
+        cfg.Pipeline = append(primefinder.Pipeline{primefinder.NewPairFinder(pairGap)}, cfg.Pipeline...)
+    }
+    startTime := time.Now()
+    count, err := streamPrimes(ctx, os.Stdout, start, end, workers, cfg, pairGap)
+    if err != nil && !errors.Is(err, primefinder.ErrCancelled) {
+        return err
+    }
+    if pairGap > 0 {
+        fmt.Fprintln(os.Stderr, tr("Found %d %s prime pairs in %v", count, pairKind, time.Since(startTime)))
+    } else {
+        fmt.Fprintln(os.Stderr, tr("Found %d primes in %v", count, time.Since(startTime)))
+    }
+    return err
+}
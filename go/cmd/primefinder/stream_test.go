@@ -0,0 +1,39 @@
+// stream_test.go
+package main
+
+import (
+    "bytes"
+    "context"
+    "errors"
+    "testing"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+// failingWriter fails every write
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestStreamPrimes(t *testing.T) {
+    var buf bytes.Buffer
+    count, err := streamPrimes(context.Background(), &buf, 1, 30, 3, primefinder.Config{}, 0)
+    if err != nil || count != 10 {
+        t.Fatalf("streamPrimes = %d, %v; expected 10 primes", count, err)
+    }
+    if expected := "2\n3\n5\n7\n11\n13\n17\n19\n23\n29\n"; buf.String() != expected {
+        t.Errorf("Streamed %q, expected %q", buf.String(), expected)
+    }
+    
+    if _, err := streamPrimes(context.Background(), failingWriter{}, 1, 100000, 3, primefinder.Config{}, 0); !errors.Is(err, primefinder.ErrSinkWrite) {
+        t.Errorf("Failing writer gave %v, expected ErrSinkWrite", err)
+    }
+    
+    // Small chunks put pair members in different chunks
+    buf.Reset()
+    pipeline, _ := primefinder.ParseTransforms("pairs:6")
+    count, err = streamPrimes(context.Background(), &buf, 1, 40, 3, primefinder.Config{MaxChunk: 3, Pipeline: pipeline}, 6)
+    if expected := "5 11\n7 13\n11 17\n13 19\n17 23\n23 29\n31 37\n"; err != nil || count != 7 || buf.String() != expected {
+        t.Errorf("Streamed sexy pairs %q (%d, %v), expected %q", buf.String(), count, err, expected)
+    }
+}
@@ -0,0 +1,149 @@
+// timings.go
+package main
+
+import (
+    "bytes"
+    "encoding/json"
+    "fmt"
+    "strings"
+    "time"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+// timeUnits maps the -time-unit names to their durations
+var timeUnits = map[string]time.Duration{
+    "ns": time.Nanosecond,
+    "us": time.Microsecond,
+    "ms": time.Millisecond,
+    "s":  time.Second,
+}
+
+// Timings splits the wall time of a run into phases, in Unit. Writing the
+// result to disk only ends once it is written, so runPhases has that for
+// the console breakdown only.
+type Timings struct {
+    Unit     string  `json:"unit"`
+    Planning float64 `json:"planning"`
+    Dispatch float64 `json:"dispatch"` // overlaps compute
+    Compute  float64 `json:"compute"`
+    Merge    float64 `json:"merge"` // overlaps compute
+    Encode   float64 `json:"encode"`
+}
+
+// runPhases accumulates the phase times of a run over its ranges
+type runPhases struct {
+    primefinder.PhaseTimes
+    Encode time.Duration
+    Write  time.Duration
+}
+
+// add adds the phases of one search
+func (p *runPhases) add(search primefinder.PhaseTimes) {
+    p.Planning += search.Planning
+    p.Dispatch += search.Dispatch
+    p.Compute += search.Compute
+    p.Merge += search.Merge
+}
+
+// timings converts the phases to the given -time-unit
+func (p runPhases) timings(unit string) *Timings {
+    return &Timings{
+        Unit:     unit,
+        Planning: inUnit(p.Planning, unit),
+        Dispatch: inUnit(p.Dispatch, unit),
+        Compute:  inUnit(p.Compute, unit),
+        Merge:    inUnit(p.Merge, unit),
+        Encode:   inUnit(p.Encode, unit),
+    }
+}
+
+// inUnit converts d to the given -time-unit
+func inUnit(d time.Duration, unit string) float64 {
+    return float64(d) / float64(timeUnits[unit])
+}
+
+// breakdown formats the phases for the console, each with its share of
+// the wall time of the run
+func (p runPhases) breakdown(wall time.Duration) string {
+    phase := func(name string, d time.Duration) string {
+        return fmt.Sprintf("%s %v (%.0f%%)", name, d.Round(time.Microsecond), share(d, wall))
+    }
+    return strings.Join([]string{
+        phase("planning", p.Planning),
+        phase("dispatch", p.Dispatch),
+        phase("compute", p.Compute),
+        phase("merge", p.Merge),
+        phase("encode", p.Encode),
+        phase("write", p.Write),
+    }, ", ")
+}
+
+const (
+    // minHintWall is the shortest run bottleneck hints are given for; in
+    // shorter runs fixed costs dominate whatever the settings
+    minHintWall = time.Second
+    // hintShare is the share of wall time past which a phase other than
+    // compute is reported as the bottleneck
+    hintShare = 25
+    // dispatchHintShare is hintShare for dispatching, which should cost
+    // next to nothing
+    dispatchHintShare = 10
+)
+
+// bottleneckHint names the phase besides compute that took the largest
+// share of a run's wall time, if that share is large enough to be worth
+// acting on, with a setting that would cut it. It returns "" otherwise.
+func (p runPhases) bottleneckHint(wall time.Duration, format string) string {
+    if wall < minHintWall {
+        return ""
+    }
+    type candidate struct {
+        d         time.Duration
+        threshold float64
+        hint      string
+    }
+    encodeHint := "output encoding consumed %.0f%% of wall time — consider -format=bin"
+    if format != "json" {
+        encodeHint = "output encoding consumed %.0f%% of wall time — consider -count-only if the primes are not needed"
+    }
+    candidates := []candidate{
+        {p.Planning, hintShare, "planning consumed %.0f%% of wall time — the range may be too small to gain from workers; consider -sequential"},
+        {p.Dispatch, dispatchHintShare, "dispatching chunks consumed %.0f%% of wall time — chunks are small; consider -scheduler static"},
+        {p.Merge, hintShare, "merging chunk results consumed %.0f%% of wall time — consider -unordered, or -count-only if the primes are not needed"},
+        {p.Encode, hintShare, encodeHint},
+        {p.Write, hintShare, "writing the output consumed %.0f%% of wall time — consider -format=bin or a faster disk"},
+    }
+    best := -1
+    for i, c := range candidates {
+        if share(c.d, wall) >= c.threshold && (best < 0 || c.d > candidates[best].d) {
+            best = i
+        }
+    }
+    if best < 0 {
+        return ""
+    }
+    return tr(candidates[best].hint, share(candidates[best].d, wall))
+}
+
+// share returns d as a percentage of wall
+func share(d, wall time.Duration) float64 {
+    if wall <= 0 {
+        return 0
+    }
+    return 100 * d.Seconds() / wall.Seconds()
+}
+
+// encodePlaceholder stands in for Timings.Encode while the result is
+// encoded, since the time that takes is only known afterwards; fillEncodeTime
+// then puts the measured value in its place. No other number in a result is
+// written the same way.
+const encodePlaceholder = 1.2345678901234567e-300
+
+// fillEncodeTime replaces encodePlaceholder in an encoded result with
+// encode. Formats that do not carry timings are returned unchanged.
+func fillEncodeTime(data []byte, encode float64) []byte {
+    placeholder, _ := json.Marshal(encodePlaceholder)
+    value, _ := json.Marshal(encode)
+    return bytes.Replace(data, placeholder, value, 1)
+}
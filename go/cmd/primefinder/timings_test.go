@@ -0,0 +1,87 @@
+// timings_test.go
+package main
+
+import (
+    "bytes"
+    "encoding/json"
+    "reflect"
+    "strings"
+    "testing"
+    "time"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+func TestRunPhasesTimings(t *testing.T) {
+    var phases runPhases
+    phases.add(primefinder.PhaseTimes{Planning: time.Millisecond, Dispatch: 200 * time.Microsecond, Compute: 2 * time.Second, Merge: 1500 * time.Microsecond})
+    phases.add(primefinder.PhaseTimes{Compute: time.Second, Dispatch: 300 * time.Microsecond})
+    
+    expected := &Timings{Unit: "ms", Planning: 1, Dispatch: 0.5, Compute: 3000, Merge: 1.5}
+    if got := phases.timings("ms"); !reflect.DeepEqual(got, expected) {
+        t.Errorf("timings(ms) = %+v, expected %+v", got, expected)
+    }
+    phases.Encode = 250 * time.Nanosecond
+    if got := phases.timings("ns"); got.Compute != 3e9 || got.Encode != 250 {
+        t.Errorf("timings(ns) = %+v", got)
+    }
+}
+
+func TestBottleneckHint(t *testing.T) {
+    s := time.Second
+    tests := []struct {
+        name     string
+        phases   runPhases
+        wall     time.Duration
+        format   string
+        expected string // start of the hint; "" for none
+    }{
+        {"compute bound", runPhases{PhaseTimes: primefinder.PhaseTimes{Compute: 9 * s, Merge: s}}, 10 * s, "json", ""},
+        {"short run", runPhases{Encode: 900 * time.Millisecond}, 950 * time.Millisecond, "json", ""},
+        {"json encoding", runPhases{PhaseTimes: primefinder.PhaseTimes{Compute: 5 * s}, Encode: 4600 * time.Millisecond}, 10 * s, "json", "output encoding consumed 46% of wall time — consider -format=bin"},
+        {"binary encoding", runPhases{Encode: 3 * s}, 10 * s, "bin", "output encoding consumed 30% of wall time — consider -count-only"},
+        {"largest wins", runPhases{PhaseTimes: primefinder.PhaseTimes{Merge: 3 * s}, Write: 4 * s}, 10 * s, "json", "writing the output consumed 40%"},
+        {"dispatch threshold", runPhases{PhaseTimes: primefinder.PhaseTimes{Dispatch: 1200 * time.Millisecond}}, 10 * s, "json", "dispatching chunks consumed 12%"},
+        {"planning", runPhases{PhaseTimes: primefinder.PhaseTimes{Planning: 6 * s}}, 10 * s, "json", "planning consumed 60%"},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got := tt.phases.bottleneckHint(tt.wall, tt.format)
+            if tt.expected == "" && got != "" || !strings.HasPrefix(got, tt.expected) {
+                t.Errorf("bottleneckHint = %q, expected %q", got, tt.expected)
+            }
+        })
+    }
+}
+
+func TestBreakdown(t *testing.T) {
+    phases := runPhases{PhaseTimes: primefinder.PhaseTimes{Planning: 250 * time.Millisecond, Compute: 750 * time.Millisecond}}
+    got := phases.breakdown(time.Second)
+    if !strings.HasPrefix(got, "planning 250ms (25%), dispatch 0s (0%), compute 750ms (75%)") {
+        t.Errorf("breakdown = %q", got)
+    }
+}
+
+func TestFillEncodeTime(t *testing.T) {
+    result := Result{RunID: "r", ExecutionTime: 1.5, Primes: []int{2, 3}, Timings: &Timings{Unit: "ms", Compute: 1500, Encode: encodePlaceholder}}
+    for _, compat := range []string{"", "js"} {
+        var buf bytes.Buffer
+        if err := (jsonWriter{compat: compat}).WriteResult(&buf, result); err != nil {
+            t.Fatal(err)
+        }
+        var decoded map[string]interface{}
+        if err := json.Unmarshal(fillEncodeTime(buf.Bytes(), 0.25), &decoded); err != nil {
+            t.Fatal(err)
+        }
+        timings := decoded["timings"].(map[string]interface{})
+        if timings["encode"] != 0.25 || timings["compute"] != 1500.0 {
+            t.Errorf("compat %q: timings %v, expected encode 0.25", compat, timings)
+        }
+    }
+    
+    var buf bytes.Buffer
+    csvWriter{}.WriteResult(&buf, result)
+    if got := fillEncodeTime(buf.Bytes(), 0.25); !bytes.Equal(got, buf.Bytes()) {
+        t.Errorf("CSV output changed to %q", got)
+    }
+}
@@ -0,0 +1,55 @@
+// workers.go
+package main
+
+import (
+    "fmt"
+    "runtime"
+    "strconv"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+// workersFlag is the -workers value of the search: a count, or auto for
+// NumCPU workers to start with that the search adds to and removes from by
+// measured throughput
+type workersFlag struct {
+    n    int
+    auto bool
+}
+
+func (w *workersFlag) String() string {
+    if w.auto {
+        return "auto"
+    }
+    return strconv.Itoa(w.n)
+}
+
+func (w *workersFlag) Set(s string) error {
+    if s == "auto" {
+        w.n, w.auto = runtime.NumCPU(), true
+        return nil
+    }
+    n, err := strconv.Atoi(s)
+    if err != nil {
+        return fmt.Errorf("invalid worker count %q: want a number or auto", s)
+    }
+    w.n, w.auto = n, false
+    return nil
+}
+
+// WorkerCount is the worker count of an autoscaled search from a point in
+// the run
+type WorkerCount struct {
+    ElapsedSeconds float64 `json:"elapsed_seconds"`
+    Workers        int     `json:"workers"`
+    Rate           float64 `json:"numbers_per_second,omitempty"` // throughput that prompted the change
+}
+
+// workerCounts converts the worker timeline of a search for the result
+func workerCounts(timeline []primefinder.WorkerSample) []WorkerCount {
+    var out []WorkerCount
+    for _, s := range timeline {
+        out = append(out, WorkerCount{ElapsedSeconds: s.Elapsed.Seconds(), Workers: s.Workers, Rate: s.Rate})
+    }
+    return out
+}
@@ -0,0 +1,50 @@
+// workers_test.go
+package main
+
+import (
+    "reflect"
+    "runtime"
+    "testing"
+    "time"
+    
+    "prime-finder/pkg/primefinder"
+)
+
+func TestWorkersFlag(t *testing.T) {
+    tests := []struct {
+        in      string
+        n       int
+        auto    bool
+        wantErr bool
+    }{
+        {"4", 4, false, false},
+        {"auto", runtime.NumCPU(), true, false},
+        {"0", 0, false, false}, // rejected later by range validation
+        {"many", 0, false, true},
+        {"Auto", 0, false, true},
+    }
+    for _, tt := range tests {
+        var w workersFlag
+        err := w.Set(tt.in)
+        if (err != nil) != tt.wantErr || err == nil && (w.n != tt.n || w.auto != tt.auto) {
+            t.Errorf("Set(%q) = %+v, %v", tt.in, w, err)
+        }
+        if err == nil && tt.auto && w.String() != "auto" {
+            t.Errorf("String() = %q, expected auto", w.String())
+        }
+    }
+}
+
+func TestWorkerCounts(t *testing.T) {
+    timeline := []primefinder.WorkerSample{
+        {Workers: 4},
+        {Elapsed: 500 * time.Millisecond, Workers: 5, Rate: 2e6},
+    }
+    expected := []WorkerCount{{0, 4, 0}, {0.5, 5, 2e6}}
+    if got := workerCounts(timeline); !reflect.DeepEqual(got, expected) {
+        t.Errorf("workerCounts = %+v, expected %+v", got, expected)
+    }
+    if got := workerCounts(nil); got != nil {
+        t.Errorf("workerCounts(nil) = %+v, expected nil", got)
+    }
+}
@@ -0,0 +1,119 @@
+// autoscale.go
+package primefinder
+
+import (
+    "context"
+    "time"
+)
+
+const (
+    // autoscaleFactor bounds an autoscaled search to this many times its
+    // starting workers
+    autoscaleFactor = 2
+    // autoscaleInterval is how often throughput is measured and the worker
+    // count reconsidered. A measurement spans more intervals until every
+    // worker could have finished a chunk in it, as throughput is only seen
+    // when chunks finish.
+    autoscaleInterval = 250 * time.Millisecond
+    // autoscaleGain is the relative change in throughput taken as real
+    // rather than noise
+    autoscaleGain = 0.05
+    // autoscaleHold is the number of intervals the worker count is left
+    // alone after a change is undone, before probing again
+    autoscaleHold = 4
+)
+
+// WorkerSample is the worker count of an autoscaled search from Elapsed
+// into it, with the throughput measured over the interval that led to it
+type WorkerSample struct {
+    Elapsed time.Duration
+    Workers int
+    Rate    float64 // numbers searched per second; 0 for the starting sample
+}
+
+// autoscaler decides how the worker count of a search changes. It climbs
+// while chunks wait in the queue, keeps a change only if throughput
+// improved by autoscaleGain, and sheds workers when the queue runs dry.
+type autoscaler struct {
+    min, max  int
+    workers   int
+    lastDelta int     // change made at the previous step
+    lastRate  float64 // throughput measured at the previous step
+    hold      int     // steps left before the next probe
+}
+
+// newAutoscaler starts at workers, staying within [1, max]
+func newAutoscaler(workers, max int) *autoscaler {
+    return &autoscaler{min: 1, max: max, workers: workers}
+}
+
+// step takes the throughput over the last interval and whether chunks are
+// waiting for a worker, and returns the change to make: -1, 0 or 1. The
+// change only counts once passed to commit, as a worker cannot always be
+// added.
+func (a *autoscaler) step(rate float64, backlog bool) int {
+    delta := 0
+    switch {
+    case a.hold > 0:
+        a.hold--
+    case a.lastDelta > 0 && rate < a.lastRate*(1+autoscaleGain):
+        // The added worker did not pay for itself
+        delta, a.hold = -1, autoscaleHold
+    case a.lastDelta < 0 && rate < a.lastRate*(1-autoscaleGain):
+        // The removed worker was pulling its weight
+        delta, a.hold = 1, autoscaleHold
+    case backlog && a.workers < a.max:
+        delta = 1
+    case !backlog && a.workers > a.min:
+        delta = -1
+    }
+    a.lastRate = rate
+    a.lastDelta = 0
+    return delta
+}
+
+// commit records a change returned by step once it has been made
+func (a *autoscaler) commit(delta int) {
+    a.workers += delta
+    if a.hold == 0 {
+        // An undone change is not itself judged
+        a.lastDelta = delta
+    }
+}
+
+// autoscale measures the throughput of team every autoscaleInterval and
+// grows or shrinks it as a decides, until done is closed or ctx is
+// cancelled. Added workers take chunks from jobs, whose length is the
+// backlog. It returns the worker count over time.
+func autoscale(ctx context.Context, done <-chan struct{}, team *crew, jobs chan chunk, a *autoscaler, started time.Time) []WorkerSample {
+    timeline := []WorkerSample{{Workers: a.workers}}
+    ticker := time.NewTicker(autoscaleInterval)
+    defer ticker.Stop()
+    searched, chunks, last := int64(0), int64(0), started
+    for {
+        select {
+        case <-done:
+            return timeline
+        case <-ctx.Done():
+            return timeline
+        case now := <-ticker.C:
+            finished := team.chunks.Load()
+            if finished-chunks < int64(a.workers) {
+                continue
+            }
+            total := team.searched.Load()
+            rate := float64(total-searched) / now.Sub(last).Seconds()
+            searched, chunks, last = total, finished, now
+            switch delta := a.step(rate, len(jobs) > 0); {
+            case delta > 0 && team.grow(jobs):
+                a.commit(delta)
+            case delta < 0:
+                team.shrink()
+                a.commit(delta)
+            default:
+                continue
+            }
+            timeline = append(timeline, WorkerSample{Elapsed: now.Sub(started), Workers: a.workers, Rate: rate})
+        }
+    }
+}
@@ -0,0 +1,91 @@
+// autoscale_test.go
+package primefinder
+
+import (
+    "context"
+    "errors"
+    "slices"
+    "testing"
+)
+
+func TestAutoscalerStep(t *testing.T) {
+    type sample struct {
+        rate    float64
+        backlog bool
+        delta   int
+    }
+    tests := []struct {
+        name    string
+        workers int
+        max     int
+        samples []sample
+    }{
+        {"climbs while throughput improves", 2, 4, []sample{
+            {100, true, 1}, {150, true, 1}, {200, true, 0}, {200, true, 0},
+        }},
+        {"undoes an addition that did not pay", 2, 4, []sample{
+            {100, true, 1}, {102, true, -1}, {100, true, 0}, {100, true, 0}, {100, true, 0}, {100, true, 0}, {100, true, 1},
+        }},
+        {"sheds workers when the queue is dry", 3, 6, []sample{
+            {100, false, -1}, {100, false, -1}, {100, false, 0},
+        }},
+        {"restores a removal that cost throughput", 3, 6, []sample{
+            {100, false, -1}, {50, false, 1}, {50, false, 0},
+        }},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            a := newAutoscaler(tt.workers, tt.max)
+            workers := tt.workers
+            for i, s := range tt.samples {
+                delta := a.step(s.rate, s.backlog)
+                a.commit(delta)
+                workers += delta
+                if delta != s.delta || a.workers != workers || workers < 1 || workers > tt.max {
+                    t.Fatalf("step %d (%v, %v) = %d with %d workers, expected %d", i, s.rate, s.backlog, delta, a.workers, s.delta)
+                }
+            }
+        })
+    }
+}
+
+func TestSearchAutoscale(t *testing.T) {
+    expected := FindRange(1, 3_000_000)
+    result := FindRangeConcurrentConfig(1, 3_000_000, 2, Config{Algorithm: AlgorithmTrial, Autoscale: true, MaxChunk: 20_000})
+    if result.Err != nil || !slices.Equal(result.Primes, expected) {
+        t.Fatalf("autoscaled search found %d primes (err %v), expected %d", len(result.Primes), result.Err, len(expected))
+    }
+    if len(result.WorkerTimeline) == 0 || result.WorkerTimeline[0].Workers != 2 {
+        t.Fatalf("timeline %+v should start at 2 workers", result.WorkerTimeline)
+    }
+    for _, s := range result.WorkerTimeline {
+        if s.Workers < 1 || s.Workers > 4 {
+            t.Errorf("sample %+v outside [1, 4] workers", s)
+        }
+    }
+    if n := len(result.Workers); n < 2 || n > 4 {
+        t.Errorf("%d worker stats, expected 2 to 4", n)
+    }
+    
+    if result := FindRangeConcurrentConfig(1, 1000, 2, Config{Autoscale: true, Deterministic: true}); !errors.Is(result.Err, ErrInvalidArgument) {
+        t.Errorf("deterministic autoscaling: got %v, expected ErrInvalidArgument", result.Err)
+    }
+    if result := FindRangeConcurrentConfig(1, 1000, 2, Config{}); result.WorkerTimeline != nil {
+        t.Errorf("timeline %+v without autoscaling", result.WorkerTimeline)
+    }
+}
+
+func TestCrewGrowReportsStart(t *testing.T) {
+    team := newCrew(context.Background(), make(chan chunkResult), Config{}, 1)
+    // Every id in use, as when a retiring worker has yet to hand its id back:
+    // the autoscaler must not count a worker that was never started
+    team.next = 1
+    if team.grow(nil) {
+        t.Error("grow reported a worker with no id free")
+    }
+    // A pending retire is cancelled instead of starting a worker
+    team.shrink()
+    if !team.grow(nil) || len(team.retire) != 0 {
+        t.Error("grow did not cancel the pending retire")
+    }
+}
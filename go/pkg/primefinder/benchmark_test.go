@@ -0,0 +1,522 @@
+// benchmark_test.go
+package primefinder
+
+import (
+    "context"
+    "errors"
+    "math/rand/v2"
+    "reflect"
+    "runtime"
+    "slices"
+    "sort"
+    "testing"
+    "time"
+)
+
+// Benchmarks for different implementations
+func BenchmarkFindPrimesSequential(b *testing.B) {
+    for i := 0; i < b.N; i++ {
+        FindRange(1, 10000)
+    }
+}
+
+func BenchmarkFindPrimesConcurrent2Workers(b *testing.B) {
+    for i := 0; i < b.N; i++ {
+        FindRangeConcurrent(1, 10000, 2)
+    }
+}
+
+func BenchmarkFindPrimesConcurrent4Workers(b *testing.B) {
+    for i := 0; i < b.N; i++ {
+        FindRangeConcurrent(1, 10000, 4)
+    }
+}
+
+func BenchmarkFindPrimesConcurrent8Workers(b *testing.B) {
+    for i := 0; i < b.N; i++ {
+        FindRangeConcurrent(1, 10000, 8)
+    }
+}
+
+func BenchmarkFindPrimesConcurrentCPUWorkers(b *testing.B) {
+    workers := runtime.NumCPU()
+    for i := 0; i < b.N; i++ {
+        FindRangeConcurrent(1, 10000, workers)
+    }
+}
+
+// Compare worker execution models on the same search
+func BenchmarkExecutorGoroutine(b *testing.B) {
+    workers := runtime.NumCPU()
+    for i := 0; i < b.N; i++ {
+        FindRangeConcurrentConfig(1, 100000, workers, Config{})
+    }
+}
+
+func BenchmarkExecutorThread(b *testing.B) {
+    workers := runtime.NumCPU()
+    for i := 0; i < b.N; i++ {
+        FindRangeConcurrentConfig(1, 100000, workers, Config{LockThreads: true})
+    }
+}
+
+// Benchmark for larger ranges
+func BenchmarkFindPrimesLargeRangeSequential(b *testing.B) {
+    for i := 0; i < b.N; i++ {
+        FindRange(1, 100000)
+    }
+}
+
+func BenchmarkFindPrimesLargeRangeConcurrent(b *testing.B) {
+    workers := runtime.NumCPU()
+    for i := 0; i < b.N; i++ {
+        FindRangeConcurrent(1, 100000, workers)
+    }
+}
+
+// Unit tests
+func TestIsPrime(t *testing.T) {
+    tests := []struct {
+        n     int
+        prime bool
+    }{
+        {1, false},
+        {2, true},
+        {3, true},
+        {4, false},
+        {5, true},
+        {6, false},
+        {7, true},
+        {8, false},
+        {9, false},
+        {10, false},
+        {11, true},
+        {100, false},
+        {101, true},
+        {1000, false},
+        {1009, true},
+        {7919, true}, // 1000th prime
+    }
+    
+    for _, tt := range tests {
+        if got := IsPrime(tt.n); got != tt.prime {
+            t.Errorf("IsPrime(%d) = %v, want %v", tt.n, got, tt.prime)
+        }
+    }
+}
+
+func TestFindPrimesInRange(t *testing.T) {
+    tests := []struct {
+        start    int
+        end      int
+        expected []int
+    }{
+        {1, 10, []int{2, 3, 5, 7}},
+        {10, 20, []int{11, 13, 17, 19}},
+        {24, 28, []int{}},
+        {17, 17, []int{17}},
+        {18, 18, []int{}},
+    }
+    
+    for _, tt := range tests {
+        primes := FindRange(tt.start, tt.end)
+        if len(primes) != len(tt.expected) {
+            t.Errorf("FindRange(%d, %d) returned %d primes, expected %d",
+                tt.start, tt.end, len(primes), len(tt.expected))
+            continue
+        }
+        
+        for i, p := range primes {
+            if p != tt.expected[i] {
+                t.Errorf("FindRange(%d, %d)[%d] = %d, expected %d",
+                    tt.start, tt.end, i, p, tt.expected[i])
+            }
+        }
+    }
+}
+
+func TestConcurrentConsistency(t *testing.T) {
+    // Test that concurrent version produces same results as sequential
+    start, end := 1, 1000
+    
+    seqPrimes := FindRange(start, end)
+    
+    for workers := 1; workers <= 8; workers *= 2 {
+        concPrimes, _ := FindRangeConcurrent(start, end, workers)
+        
+        if len(concPrimes) != len(seqPrimes) {
+            t.Errorf("Concurrent with %d workers found %d primes, expected %d",
+                workers, len(concPrimes), len(seqPrimes))
+        }
+        
+        // Check that all primes match
+        seqMap := make(map[int]bool)
+        for _, p := range seqPrimes {
+            seqMap[p] = true
+        }
+        
+        for _, p := range concPrimes {
+            if !seqMap[p] {
+                t.Errorf("Concurrent with %d workers found incorrect prime: %d",
+                    workers, p)
+            }
+        }
+    }
+}
+
+func TestConcurrentSorted(t *testing.T) {
+    // Many small chunks finish out of order; output must still ascend
+    primes, _ := FindRangeConcurrent(1, 5000, 7)
+    for i := 1; i < len(primes); i++ {
+        if primes[i] <= primes[i-1] {
+            t.Fatalf("Primes not ascending at index %d: %d after %d",
+                i, primes[i], primes[i-1])
+        }
+    }
+}
+
+func TestEmptyRange(t *testing.T) {
+    primes := FindRange(0, 1)
+    if len(primes) != 0 {
+        t.Errorf("Expected no primes in range [0,1], got %v", primes)
+    }
+    
+    // Test reverse range
+    primes = FindRange(10, 5)
+    if len(primes) != 0 {
+        t.Errorf("Expected no primes in reverse range, got %v", primes)
+    }
+}
+
+func TestLargePrimeCount(t *testing.T) {
+    // There are 168 primes less than 1000
+    primes := FindRange(1, 1000)
+    if len(primes) != 168 {
+        t.Errorf("Expected 168 primes under 1000, got %d", len(primes))
+    }
+    
+    // There are 78498 primes less than 1000000
+    // Skip this test in short mode as it's slow
+    if !testing.Short() {
+        primes = FindRange(1, 1000000)
+        if len(primes) != 78498 {
+            t.Errorf("Expected 78498 primes under 1000000, got %d", len(primes))
+        }
+    }
+}
+
+func TestWorkerPoolEdgeCases(t *testing.T) {
+    // Test with more workers than range size
+    primes, _ := FindRangeConcurrent(1, 10, 100)
+    expected := []int{2, 3, 5, 7}
+    
+    if len(primes) != len(expected) {
+        t.Errorf("Expected %d primes with many workers, got %d", 
+            len(expected), len(primes))
+    }
+}
+
+func TestThreadExecutorConsistency(t *testing.T) {
+    result := FindRangeConcurrentConfig(1, 1000, 4, Config{LockThreads: true})
+    if len(result.Primes) != 168 {
+        t.Errorf("Thread executor found %d primes under 1000, expected 168", len(result.Primes))
+    }
+}
+
+func TestChunkQuarantine(t *testing.T) {
+    // A timeout no chunk can meet quarantines every chunk
+    result := FindRangeConcurrentConfig(1, 100000, 4, Config{
+        ChunkTimeout: time.Nanosecond,
+        ChunkRetries: 1,
+        Scheduler:    SchedulerStatic,
+    })
+    if len(result.Quarantined) != 4 {
+        t.Errorf("Expected 4 quarantined chunks, got %v", result.Quarantined)
+    }
+    if len(result.Primes) != 0 {
+        t.Errorf("Expected no primes from quarantined chunks, got %d", len(result.Primes))
+    }
+    
+    // A generous timeout quarantines nothing
+    result = FindRangeConcurrentConfig(1, 1000, 4, Config{ChunkTimeout: time.Minute})
+    if len(result.Quarantined) != 0 || len(result.Primes) != 168 {
+        t.Errorf("Expected 168 primes and no quarantine, got %d primes, %v",
+            len(result.Primes), result.Quarantined)
+    }
+}
+
+// Benchmark the IsPrime function itself
+func BenchmarkIsPrime(b *testing.B) {
+    for i := 0; i < b.N; i++ {
+        IsPrime(1000003) // A known large prime
+    }
+}
+
+func BenchmarkIsPrimeNonPrime(b *testing.B) {
+    for i := 0; i < b.N; i++ {
+        IsPrime(1000000) // A non-prime
+    }
+}
+
+// Allocation profile of the results path at high worker counts
+func BenchmarkFindPrimesConcurrentAllocs32Workers(b *testing.B) {
+    b.ReportAllocs()
+    for i := 0; i < b.N; i++ {
+        FindRangeConcurrent(1, 200000, 32)
+    }
+}
+
+func TestConcurrentCancellation(t *testing.T) {
+    // Cancelled before starting: nothing is searched
+    ctx, cancel := context.WithCancel(context.Background())
+    cancel()
+    result := FindRangeConcurrentContext(ctx, 1, 1000000, 4, Config{})
+    if !result.Cancelled || len(result.Primes) != 0 {
+        t.Fatalf("Cancelled search returned %d primes, cancelled=%v", len(result.Primes), result.Cancelled)
+    }
+    if len(result.Unsearched) != 1 || result.Unsearched[0] != [2]int{1, 1000000} {
+        t.Errorf("Unsearched = %v, expected the whole range", result.Unsearched)
+    }
+    
+    // Cancelled part way: the primes found are exactly those of the chunks
+    // not reported as unsearched
+    for _, algorithm := range []string{AlgorithmTrial, AlgorithmSieve} {
+        ctx, cancel := context.WithCancel(context.Background())
+        cfg := Config{Algorithm: algorithm, OnProgress: func(p Progress) {
+            if p.ChunksDone == 2 {
+                cancel()
+            }
+        }}
+        result := FindRangeConcurrentContext(ctx, 1, 2000000, 8, cfg)
+        cancel()
+        
+        expected := 0
+        for _, p := range FindRange(1, 2000000) {
+            searched := true
+            for _, u := range result.Unsearched {
+                if p >= u[0] && p <= u[1] {
+                    searched = false
+                }
+            }
+            if searched {
+                expected++
+            }
+        }
+        if len(result.Primes) != expected {
+            t.Errorf("%s: partial search found %d primes outside %v, expected %d", algorithm, len(result.Primes), result.Unsearched, expected)
+        }
+    }
+}
+
+func TestConcurrentUnordered(t *testing.T) {
+    expected := FindRange(1, 100000)
+    result := FindRangeConcurrentConfig(1, 100000, 8, Config{Unordered: true})
+    sort.Ints(result.Primes)
+    if len(result.Primes) != len(expected) {
+        t.Fatalf("Unordered search found %d primes, expected %d", len(result.Primes), len(expected))
+    }
+    for i := range expected {
+        if result.Primes[i] != expected[i] {
+            t.Fatalf("Sorted unordered prime[%d] = %d, expected %d", i, result.Primes[i], expected[i])
+        }
+    }
+}
+
+func TestProgressCovered(t *testing.T) {
+    // The covered prefix only ever grows and ends at the whole range, in
+    // either merge order
+    for _, unordered := range []bool{false, true} {
+        var last Progress
+        cfg := Config{Unordered: unordered, OnProgress: func(p Progress) {
+            if p.Covered < last.Covered || p.CoveredPrimes > p.Primes {
+                t.Errorf("unordered=%v: covered went from %d to %d with %d of %d primes",
+                    unordered, last.Covered, p.Covered, p.CoveredPrimes, p.Primes)
+            }
+            last = p
+        }}
+        FindRangeConcurrentConfig(1000, 500000, 8, cfg)
+        if last.Covered != 500000 || last.CoveredPrimes != len(FindRange(1000, 500000)) {
+            t.Errorf("unordered=%v: final covered %d with %d primes", unordered, last.Covered, last.CoveredPrimes)
+        }
+    }
+}
+
+func TestCoverage(t *testing.T) {
+    c := coverage{end: 9}
+    c.add(20, 29, 2)
+    c.add(30, 39, 2)
+    if c.end != 9 || c.primes != 0 {
+        t.Fatalf("chunks past a gap were covered: %+v", c)
+    }
+    c.add(10, 19, 4)
+    if c.end != 39 || c.primes != 8 || len(c.pending) != 0 {
+        t.Errorf("filling the gap left %+v, expected end 39 with 8 primes", c)
+    }
+}
+
+func TestSoftDeadline(t *testing.T) {
+    // Trial division up to 10^9 cannot finish in time; what is reported as
+    // covered must be complete and everything past it listed as unsearched
+    result := FindRangeConcurrentConfig(1, 1000000000, 4, Config{
+        Algorithm:    AlgorithmTrial,
+        SoftDeadline: time.Now().Add(300 * time.Millisecond),
+    })
+    if !result.DeadlineReached || result.Cancelled {
+        t.Fatalf("DeadlineReached=%v Cancelled=%v, expected the deadline to be reached", result.DeadlineReached, result.Cancelled)
+    }
+    if result.Covered < 1 || len(result.Unsearched) == 0 || result.Unsearched[0][0] <= result.Covered {
+        t.Fatalf("covered through %d with unsearched %v", result.Covered, result.Unsearched)
+    }
+    if expected := len(FindRange(1, result.Covered)); result.CoveredPrimes != expected {
+        t.Errorf("%d primes in the covered prefix [1, %d], expected %d", result.CoveredPrimes, result.Covered, expected)
+    }
+    
+    // A deadline that is never reached changes nothing
+    result = FindRangeConcurrentConfig(1, 100000, 4, Config{SoftDeadline: time.Now().Add(time.Minute)})
+    if result.DeadlineReached || result.Covered != 100000 || len(result.Primes) != 9592 {
+        t.Errorf("DeadlineReached=%v covered %d with %d primes, expected all 9592 up to 100000",
+            result.DeadlineReached, result.Covered, len(result.Primes))
+    }
+}
+
+func TestLimit(t *testing.T) {
+    all := FindRange(1000000, 3000000)
+    for _, cfg := range []Config{{Limit: 1000}, {Limit: 1000, Algorithm: AlgorithmSieve}, {Limit: len(all) + 1}} {
+        result := FindRangeConcurrentConfig(1000000, 3000000, 4, cfg)
+        expected := all[:min(cfg.Limit, len(all))]
+        if len(result.Primes) != len(expected) || result.Primes[len(result.Primes)-1] != expected[len(expected)-1] {
+            t.Errorf("limit %d (%s): got %d primes, expected the first %d", cfg.Limit, cfg.Algorithm, len(result.Primes), len(expected))
+        }
+        if result.LimitReached != (cfg.Limit <= len(all)) || result.Cancelled || len(result.Unsearched) > 0 {
+            t.Errorf("limit %d: LimitReached=%v Cancelled=%v Unsearched=%v", cfg.Limit, result.LimitReached, result.Cancelled, result.Unsearched)
+        }
+    }
+    
+    if result := FindRangeConcurrentConfig(1, 100, 2, Config{Limit: 5, Unordered: true}); !errors.Is(result.Err, ErrInvalidArgument) {
+        t.Errorf("limit with unordered merging: got %v, expected ErrInvalidArgument", result.Err)
+    }
+}
+
+func TestDescending(t *testing.T) {
+    expected := FindRange(1, 300000)
+    slices.Reverse(expected)
+    for _, algorithm := range []string{AlgorithmTrial, AlgorithmSieve} {
+        var last Progress
+        result := FindRangeConcurrentConfig(1, 300000, 4, Config{Algorithm: algorithm, Descending: true, OnProgress: func(p Progress) {
+            last = p
+        }})
+        if !slices.Equal(result.Primes, expected) {
+            t.Errorf("%s: descending search found %d primes, not the %d expected in descending order", algorithm, len(result.Primes), len(expected))
+        }
+        if result.Covered != 1 || last.Covered != 1 {
+            t.Errorf("%s: covered down to %d (progress %d), expected 1", algorithm, result.Covered, last.Covered)
+        }
+    }
+    
+    // A limit keeps the highest primes
+    result := FindRangeConcurrentConfig(1, 300000, 4, Config{Descending: true, Limit: 10})
+    if !slices.Equal(result.Primes, expected[:10]) || result.Covered != expected[9] {
+        t.Errorf("descending limit 10: got %v covering down to %d, expected %v", result.Primes, result.Covered, expected[:10])
+    }
+    
+    // Nothing searched: the whole range is unsearched and nothing covered
+    ctx, cancel := context.WithCancel(context.Background())
+    cancel()
+    result = FindRangeConcurrentContext(ctx, 1, 300000, 4, Config{Descending: true})
+    if len(result.Unsearched) != 1 || result.Unsearched[0] != [2]int{1, 300000} || result.Covered != 300001 {
+        t.Errorf("cancelled descending search: unsearched %v, covered %d", result.Unsearched, result.Covered)
+    }
+}
+
+func TestCountOnly(t *testing.T) {
+    const start, end = 1, 2000000
+    all := FindRange(start, end)
+    for _, cfg := range []Config{
+        {Algorithm: AlgorithmTrial},
+        {Algorithm: AlgorithmSieve},
+        {Algorithm: AlgorithmMillerRabin},
+        {Algorithm: AlgorithmSieve, Stride: 4, Offset: 3},
+        {Algorithm: AlgorithmTrial, Stride: 4, Offset: 3},
+        {Algorithm: AlgorithmSieve, Descending: true, Unordered: true},
+    } {
+        expected := len(keepCandidates(slices.Clone(all), cfg.Stride, cfg.Offset))
+        var last Progress
+        cfg.OnProgress = func(p Progress) { last = p }
+        cfg.CountOnly = true
+        result := FindRangeConcurrentConfig(start, end, 4, cfg)
+        if result.Err != nil || result.Count != expected || result.Primes != nil || last.Primes != expected || result.CoveredPrimes != expected {
+            t.Errorf("count-only %s stride %d: count %d (progress %d, covered %d), primes %d, err %v; expected %d",
+                cfg.Algorithm, cfg.Stride, result.Count, last.Primes, result.CoveredPrimes, len(result.Primes), result.Err, expected)
+        }
+    }
+    
+    // Stored results report the same count
+    if result := FindRangeConcurrentConfig(start, end, 4, Config{}); result.Count != len(all) {
+        t.Errorf("Count = %d, expected %d", result.Count, len(all))
+    }
+    if result := FindRangeConcurrentConfig(1, 100, 2, Config{Limit: 5, CountOnly: true}); !errors.Is(result.Err, ErrInvalidArgument) {
+        t.Errorf("limit with count-only: got %v, expected ErrInvalidArgument", result.Err)
+    }
+}
+
+func TestDeterministic(t *testing.T) {
+    const start, end, workers = 1, 500000, 4
+    cfg := Config{Algorithm: AlgorithmMillerRabin, MRRounds: 1, Deterministic: true, Seed: 7}
+    plan, _ := planChunks(start, end, workers, "", 0, nil)
+    
+    var first SearchResult
+    for run := 0; run < 3; run++ {
+        result := FindRangeConcurrentConfig(start, end, workers, cfg)
+        if result.Err != nil {
+            t.Fatalf("run %d: %v", run, result.Err)
+        }
+        // Chunk i goes to worker i mod workers, however fast each runs
+        for i, stats := range result.Workers {
+            if expected := (len(plan) - i + workers - 1) / workers; stats.Chunks != expected {
+                t.Errorf("run %d: worker %d took %d chunks, expected %d", run, i, stats.Chunks, expected)
+            }
+        }
+        if run == 0 {
+            first = result
+            continue
+        }
+        if !reflect.DeepEqual(result.Primes, first.Primes) || len(result.ChunkCosts) != len(first.ChunkCosts) {
+            t.Errorf("run %d: %d primes in %d chunks, first run %d in %d", run, len(result.Primes), len(result.ChunkCosts), len(first.Primes), len(first.ChunkCosts))
+        }
+    }
+    
+    // Each chunk's bases are seeded, so the same composites pass every run
+    rng := func() *rand.Rand { return rand.New(rand.NewPCG(7, 1)) }
+    a, b := testRangeUntilRand(rng()), testRangeUntilRand(rng())
+    if !reflect.DeepEqual(a, b) {
+        t.Errorf("seeded Miller-Rabin differs between runs: %d and %d primes", len(a), len(b))
+    }
+    
+    for _, bad := range []Config{
+        {Deterministic: true, Unordered: true},
+        {Deterministic: true, ChunkTimeout: time.Second},
+        {Deterministic: true, SoftDeadline: time.Now().Add(time.Minute)},
+    } {
+        if result := FindRangeConcurrentConfig(1, 100, 2, bad); !errors.Is(result.Err, ErrInvalidArgument) {
+            t.Errorf("%+v: got %v, expected ErrInvalidArgument", bad, result.Err)
+        }
+    }
+}
+
+// testRangeUntilRand tests the odd numbers below 2^20 with one Miller-Rabin
+// round, so strong liars pass now and then
+func testRangeUntilRand(rng *rand.Rand) []int {
+    primes, _ := testRangeUntil(context.Background(), 3, 1<<20, 2, mrTest(1, rng), time.Time{})
+    return primes
+}
+
+func TestSearchPhases(t *testing.T) {
+    result := FindRangeConcurrentConfig(1, 2_000_000, 3, Config{})
+    p := result.Phases
+    if p.Planning <= 0 || p.Dispatch <= 0 || p.Compute <= 0 || p.Merge <= 0 {
+        t.Errorf("every phase should take some time, got %+v", p)
+    }
+    if p.Planning+p.Compute > result.Duration || p.Dispatch > result.Duration || p.Merge > result.Duration {
+        t.Errorf("phases %+v exceed the search duration %v", p, result.Duration)
+    }
+}
@@ -0,0 +1,83 @@
+// big.go
+package primefinder
+
+import (
+    "fmt"
+    "math"
+    "math/big"
+    "sync"
+    "time"
+)
+
+// bigRounds is the number of Miller-Rabin rounds run before Baillie-PSW
+const bigRounds = 20
+
+// ValidateBigRange checks an arbitrary precision range. The bounds may be
+// any size but the width must fit in an int.
+func ValidateBigRange(start, end *big.Int, workers int) error {
+    if start.Cmp(end) > 0 {
+        return fmt.Errorf("%w: start %s is greater than end %s", ErrInvalidRange, start, end)
+    }
+    if width := new(big.Int).Sub(end, start); !width.IsInt64() || width.Int64() >= math.MaxInt {
+        return fmt.Errorf("%w: range [%s, %s] is too wide", ErrInvalidRange, start, end)
+    }
+    if workers < 1 {
+        return fmt.Errorf("%w: workers must be at least 1, got %d", ErrInvalidArgument, workers)
+    }
+    return nil
+}
+
+// FindRangeBig finds the probable primes in [start, end] with concurrent
+// workers, for bounds beyond the int range. Each candidate is tested with
+// big.Int.ProbablyPrime. Primes are returned in ascending order.
+func FindRangeBig(start, end *big.Int, workers int) ([]*big.Int, time.Duration) {
+    startTime := time.Now()
+    if start.Cmp(end) > 0 {
+        return nil, time.Since(startTime)
+    }
+    
+    width := int(new(big.Int).Sub(end, start).Int64()) + 1
+    chunkSize := max(width/workers, 1)
+    chunks := (width + chunkSize - 1) / chunkSize
+    found := make([][]*big.Int, chunks)
+    
+    jobs := make(chan int, workers)
+    var wg sync.WaitGroup
+    for i := 0; i < workers; i++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for seq := range jobs {
+                lo := seq * chunkSize
+                hi := min(lo+chunkSize, width)
+                found[seq] = probablePrimesBig(start, lo, hi)
+            }
+        }()
+    }
+    for seq := 0; seq < chunks; seq++ {
+        jobs <- seq
+    }
+    close(jobs)
+    wg.Wait()
+    
+    var primes []*big.Int
+    for _, part := range found {
+        primes = append(primes, part...)
+    }
+    return primes, time.Since(startTime)
+}
+
+// probablePrimesBig tests base+lo up to (not including) base+hi
+func probablePrimesBig(base *big.Int, lo, hi int) []*big.Int {
+    var primes []*big.Int
+    n := new(big.Int).Add(base, big.NewInt(int64(lo)))
+    one, two := big.NewInt(1), big.NewInt(2)
+    for i := lo; i < hi; i++ {
+        // Skip even candidates without the call
+        if (n.Bit(0) == 1 || n.Cmp(two) == 0) && n.ProbablyPrime(bigRounds) {
+            primes = append(primes, new(big.Int).Set(n))
+        }
+        n.Add(n, one)
+    }
+    return primes
+}
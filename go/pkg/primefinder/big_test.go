@@ -0,0 +1,60 @@
+// big_test.go
+package primefinder
+
+import (
+    "errors"
+    "math/big"
+    "testing"
+)
+
+func TestFindRangeBigMatchesInt(t *testing.T) {
+    expected := FindRange(1, 5000)
+    for _, workers := range []int{1, 3, 8} {
+        primes, _ := FindRangeBig(big.NewInt(1), big.NewInt(5000), workers)
+        if len(primes) != len(expected) {
+            t.Fatalf("%d workers found %d primes, expected %d", workers, len(primes), len(expected))
+        }
+        for i, p := range primes {
+            if !p.IsInt64() || p.Int64() != int64(expected[i]) {
+                t.Fatalf("%d workers: prime[%d] = %s, expected %d", workers, i, p, expected[i])
+            }
+        }
+    }
+}
+
+func TestFindRangeBigAbove2To64(t *testing.T) {
+    // The primes just above 2^64 are 2^64+13 and 2^64+37
+    start, _ := new(big.Int).SetString("18446744073709551616", 10)
+    end := new(big.Int).Add(start, big.NewInt(40))
+    primes, _ := FindRangeBig(start, end, 4)
+    
+    expected := []string{"18446744073709551629", "18446744073709551653"}
+    if len(primes) != len(expected) {
+        t.Fatalf("Found %v, expected %v", primes, expected)
+    }
+    for i := range primes {
+        if primes[i].String() != expected[i] {
+            t.Errorf("prime[%d] = %s, expected %s", i, primes[i], expected[i])
+        }
+    }
+}
+
+func TestValidateBigRange(t *testing.T) {
+    huge := new(big.Int).Lsh(big.NewInt(1), 100)
+    tests := []struct {
+        name       string
+        start, end *big.Int
+        workers    int
+        expected   error
+    }{
+        {"valid", huge, new(big.Int).Add(huge, big.NewInt(1000)), 4, nil},
+        {"reversed", big.NewInt(10), big.NewInt(5), 4, ErrInvalidRange},
+        {"too wide", big.NewInt(0), huge, 4, ErrInvalidRange},
+        {"no workers", big.NewInt(1), big.NewInt(5), 0, ErrInvalidArgument},
+    }
+    for _, tt := range tests {
+        if err := ValidateBigRange(tt.start, tt.end, tt.workers); !errors.Is(err, tt.expected) {
+            t.Errorf("%s: ValidateBigRange = %v, expected %v", tt.name, err, tt.expected)
+        }
+    }
+}
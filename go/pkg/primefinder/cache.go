@@ -0,0 +1,66 @@
+// cache.go
+package primefinder
+
+import (
+    "sort"
+    "sync"
+    "sync/atomic"
+)
+
+// DefaultCacheLimit is the bound the base-prime cache is built to on first
+// use, enough for trial division of every number up to 10^12
+const DefaultCacheLimit = 1_000_000
+
+// primeTable holds the primes up to limit, ascending
+type primeTable struct {
+    limit  int
+    primes []int
+}
+
+var (
+    // primeCache is shared by every search; a table is never modified once
+    // stored, only replaced by a larger one
+    primeCache atomic.Pointer[primeTable]
+    // cacheMu serializes building tables
+    cacheMu sync.Mutex
+)
+
+// WarmCache builds the cache of base primes up to limit ahead of use, so
+// trial division and sieving divide by primes rather than every candidate
+// and no search rebuilds its base primes. The cache only grows: a limit
+// below the current one does nothing. Without a call the cache is built to
+// DefaultCacheLimit on first use. It is safe to call concurrently with
+// searches, which keep the table they started with.
+func WarmCache(limit int) {
+    cacheMu.Lock()
+    defer cacheMu.Unlock()
+    if t := primeCache.Load(); t != nil && t.limit >= limit {
+        return
+    }
+    primeCache.Store(&primeTable{limit: limit, primes: basePrimes(limit)})
+}
+
+// CacheLimit returns the bound the base-prime cache currently reaches
+func CacheLimit() int {
+    return loadCache().limit
+}
+
+// loadCache returns the cached table, building the default one if needed
+func loadCache() *primeTable {
+    if t := primeCache.Load(); t != nil {
+        return t
+    }
+    WarmCache(DefaultCacheLimit)
+    return primeCache.Load()
+}
+
+// sievingPrimes returns the primes up to limit, from the cache if it reaches
+// that far and from a fresh sieve otherwise
+func sievingPrimes(limit int) []int {
+    t := loadCache()
+    if limit > t.limit {
+        return basePrimes(limit)
+    }
+    i := sort.SearchInts(t.primes, limit+1)
+    return t.primes[:i:i]
+}
@@ -0,0 +1,42 @@
+// cache_test.go
+package primefinder
+
+import (
+    "reflect"
+    "sync"
+    "testing"
+)
+
+func TestWarmCache(t *testing.T) {
+    if limit := CacheLimit(); limit < DefaultCacheLimit {
+        t.Fatalf("CacheLimit() = %d, expected at least %d", limit, DefaultCacheLimit)
+    }
+    
+    // The cache only grows, and concurrent warming builds one table
+    var wg sync.WaitGroup
+    for i := 0; i < 4; i++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            WarmCache(2 * DefaultCacheLimit)
+            IsPrime(1_000_000_007)
+        }()
+    }
+    wg.Wait()
+    WarmCache(10)
+    if limit := CacheLimit(); limit != 2*DefaultCacheLimit {
+        t.Errorf("CacheLimit() = %d, expected %d", limit, 2*DefaultCacheLimit)
+    }
+    
+    for _, limit := range []int{0, 1, 2, 100, 7919, 2 * DefaultCacheLimit, 2*DefaultCacheLimit + 100} {
+        if got, expected := sievingPrimes(limit), basePrimes(limit); len(got) != len(expected) || len(got) > 0 && !reflect.DeepEqual(got, expected) {
+            t.Errorf("sievingPrimes(%d) returned %d primes, expected %d", limit, len(got), len(expected))
+        }
+    }
+    // Callers may append to the slice without touching the cache
+    small := sievingPrimes(10)
+    _ = append(small, 0)
+    if loadCache().primes[4] != 11 {
+        t.Error("appending to sievingPrimes overwrote the cache")
+    }
+}
@@ -0,0 +1,43 @@
+// candidates.go
+package primefinder
+
+import "fmt"
+
+// ValidateStride checks a candidate set of the numbers congruent to offset
+// modulo stride; a stride of 0 or 1 selects every number
+func ValidateStride(stride, offset int) error {
+    if stride < 0 {
+        return fmt.Errorf("%w: stride must not be negative, got %d", ErrInvalidArgument, stride)
+    }
+    if stride <= 1 && offset != 0 {
+        return fmt.Errorf("%w: offset %d needs a stride above 1", ErrInvalidArgument, offset)
+    }
+    return nil
+}
+
+// firstCandidate returns the least n >= start with n = offset (mod stride)
+func firstCandidate(start, stride, offset int) int {
+    if stride <= 1 {
+        return start
+    }
+    // Go's % keeps the sign of the dividend, so normalise both residues
+    want := (offset%stride + stride) % stride
+    have := (start%stride + stride) % stride
+    return start + (want-have+stride)%stride
+}
+
+// keepCandidates filters ascending primes in place to those congruent to
+// offset modulo stride, for algorithms that find every prime in a range
+func keepCandidates(primes []int, stride, offset int) []int {
+    if stride <= 1 {
+        return primes
+    }
+    want := (offset%stride + stride) % stride
+    kept := primes[:0]
+    for _, p := range primes {
+        if p%stride == want {
+            kept = append(kept, p)
+        }
+    }
+    return kept
+}
@@ -0,0 +1,73 @@
+// candidates_test.go
+package primefinder
+
+import (
+    "errors"
+    "slices"
+    "testing"
+)
+
+func TestFirstCandidate(t *testing.T) {
+    tests := []struct {
+        start, stride, offset int
+        expected              int
+    }{
+        {10, 1, 0, 10},
+        {10, 4, 3, 11},
+        {11, 4, 3, 11},
+        {12, 4, 3, 15},
+        {10, 4, 7, 11},
+        {10, 4, -1, 11},
+        {-5, 4, 3, -5},
+    }
+    for _, tt := range tests {
+        if got := firstCandidate(tt.start, tt.stride, tt.offset); got != tt.expected {
+            t.Errorf("firstCandidate(%d, %d, %d) = %d, expected %d", tt.start, tt.stride, tt.offset, got, tt.expected)
+        }
+    }
+}
+
+func TestValidateStride(t *testing.T) {
+    tests := []struct {
+        stride, offset int
+        valid          bool
+    }{
+        {0, 0, true},
+        {1, 0, true},
+        {4, 3, true},
+        {-2, 1, false},
+        {0, 1, false},
+    }
+    for _, tt := range tests {
+        err := ValidateStride(tt.stride, tt.offset)
+        if tt.valid != (err == nil) || err != nil && !errors.Is(err, ErrInvalidArgument) {
+            t.Errorf("ValidateStride(%d, %d) = %v", tt.stride, tt.offset, err)
+        }
+    }
+}
+
+func TestStridedSearch(t *testing.T) {
+    // Every algorithm, sequential and concurrent, finds exactly the primes
+    // of the residue class
+    var expected []int
+    for _, p := range FindRange(1, 200000) {
+        if p%4 == 3 {
+            expected = append(expected, p)
+        }
+    }
+    for _, algorithm := range []string{AlgorithmTrial, AlgorithmSieve, AlgorithmMillerRabin} {
+        cfg := Config{Algorithm: algorithm, Stride: 4, Offset: 3}
+        if got, err := FindRangeConfig(1, 200000, cfg); err != nil || !slices.Equal(got, expected) {
+            t.Errorf("%s: sequential strided search found %d primes (%v), expected %d", algorithm, len(got), err, len(expected))
+        }
+        if got := FindRangeConcurrentConfig(1, 200000, 4, cfg); !slices.Equal(got.Primes, expected) {
+            t.Errorf("%s: concurrent strided search found %d primes, expected %d", algorithm, len(got.Primes), len(expected))
+        }
+    }
+    
+    // Primes of the form k*2^10+1
+    got, _ := FindRangeConfig(1, 70000, Config{Stride: 1 << 10, Offset: 1})
+    if !slices.Equal(got, []int{12289, 13313, 15361, 18433, 19457, 25601, 37889, 39937, 40961, 50177, 58369, 59393, 61441, 64513, 65537}) {
+        t.Errorf("primes k*2^10+1 up to 70000 = %v", got)
+    }
+}
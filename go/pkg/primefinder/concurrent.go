@@ -0,0 +1,720 @@
+// concurrent.go
+package primefinder
+
+import (
+    "context"
+    "fmt"
+    "math"
+    "math/rand/v2"
+    "runtime"
+    "runtime/trace"
+    "slices"
+    "sort"
+    "sync"
+    "sync/atomic"
+    "time"
+)
+
+// Config holds optional tuning for a concurrent search
+type Config struct {
+    ChunkTimeout  time.Duration  // per-attempt limit for one chunk; 0 disables
+    ChunkRetries  int            // extra attempts before a chunk is quarantined
+    LockThreads   bool           // pin each worker to its own OS thread
+    Algorithm     string         // AlgorithmAuto (default), AlgorithmTrial, AlgorithmSieve or AlgorithmMillerRabin
+    MRRounds      int            // Miller-Rabin rounds with random bases; 0 uses the exact 64-bit witness set
+    OnProgress    func(Progress) // called from the collecting goroutine after each chunk is merged
+    Unordered     bool           // take chunks in completion order instead of range order
+    Scheduler     string         // SchedulerDynamic (default) or SchedulerStatic
+    MaxChunk      int            // largest chunk the scheduler hands out; 0 for no limit
+    SoftDeadline  time.Time      // stop searching at this time, keeping the searched prefix contiguous; zero for none
+    Limit         int            // stop once this many primes are found, keeping the first in search order; 0 for no limit
+    Descending    bool           // search from end down to start and emit primes in descending order
+    Stride        int            // with Offset, only test numbers congruent to Offset modulo Stride; 0 or 1 tests all
+    Offset        int            // see Stride
+    CountOnly     bool           // count primes per chunk without storing them; Primes stays empty
+    Wheel         int            // wheel size for trial division and the sieve: 0 (default), 6, 30 or 210
+    Deterministic bool           // make the same runtime decisions on every run; see ValidateDeterministic
+    Seed          uint64         // with Deterministic, seeds the random Miller-Rabin bases
+    Autoscale     bool           // add and remove workers by measured throughput, between one and twice the starting count
+    Pipeline      Pipeline       // transforms run over each merged chunk's primes; Primes holds what they emit
+    
+    basePrimes []int      // primes up to sqrt(end), shared by sieving workers
+    rng        *rand.Rand // random bases of the chunk being searched; nil uses the global generator
+}
+
+// ValidateDeterministic checks that a configuration can run with
+// Config.Deterministic. A deterministic search hands chunk i of the plan to
+// worker i mod workers instead of to whichever worker is free, merges chunks
+// in range order, and seeds the random bases of each chunk from Seed and the
+// chunk start, so every run makes the same decisions. Settings that react to
+// elapsed time are refused: chunk timeouts, the soft deadline,
+// completion-order merging and autoscaling.
+func ValidateDeterministic(cfg Config) error {
+    if cfg.Deterministic && (cfg.Unordered || cfg.ChunkTimeout > 0 || !cfg.SoftDeadline.IsZero() || cfg.Autoscale) {
+        return fmt.Errorf("%w: a deterministic search cannot use chunk timeouts, a soft deadline, unordered merging or autoscaling", ErrInvalidArgument)
+    }
+    return nil
+}
+
+// Outcomes of a chunk, as reported in ChunkReport.Status
+const (
+    ChunkDone        = "done"        // searched in full
+    ChunkQuarantined = "quarantined" // dropped after repeated timeouts
+    ChunkCancelled   = "cancelled"   // interrupted by cancellation, the soft deadline or the limit
+    ChunkLost        = "lost"        // its worker panicked
+)
+
+// ChunkReport describes one merged chunk
+type ChunkReport struct {
+    Start    int     `json:"start"`
+    End      int     `json:"end"`
+    Primes   int     `json:"primes"`
+    Seconds  float64 `json:"seconds"`
+    Attempts int     `json:"attempts"` // more than one means the chunk timed out and was retried
+    Status   string  `json:"status"`   // ChunkDone, ChunkQuarantined, ChunkCancelled or ChunkLost
+}
+
+// ChunkCost is the measured compute time of one chunk
+type ChunkCost struct {
+    Start   int
+    End     int
+    Seconds float64
+}
+
+// Progress describes how far a concurrent search has got. Chunks are counted
+// once they are merged, which happens in range order unless Config.Unordered
+// is set. With Config.Descending the covered prefix is searched from the end
+// of the range, so Covered is its lowest number, or end+1 if none.
+type Progress struct {
+    ChunksDone    int
+    Chunks        int
+    Searched      int // numbers in the merged chunks
+    Width         int // numbers in the whole range
+    Primes        int // primes found in the merged chunks
+    Covered       int // end of the longest fully searched prefix; start-1 if none
+    CoveredPrimes int // primes found in that prefix
+    Elapsed       time.Duration
+    Last          ChunkReport // the chunk whose merge triggered this report
+}
+
+// SearchResult is the outcome of a concurrent search
+type SearchResult struct {
+    Primes          []int
+    Count           int // primes found, which with Config.CountOnly are not kept in Primes
+    Duration        time.Duration
+    Quarantined     [][2]int       // chunks dropped after repeated timeouts
+    ChunkCosts      []ChunkCost    // compute time of every chunk, in merge order
+    Algorithm       string         // algorithm the search ran with
+    Cancelled       bool           // the context was cancelled; Primes is partial
+    Unsearched      [][2]int       // ranges skipped or interrupted by cancellation or the soft deadline
+    Covered         int            // end of the longest fully searched prefix; start-1 if none
+    CoveredPrimes   int            // primes found in that prefix
+    DeadlineReached bool           // the soft deadline passed before the range was covered
+    LimitReached    bool           // Config.Limit primes were found; Covered ends at the last of them
+    Workers         []WorkerStats  // per-worker activity, indexed by worker
+    WorkerTimeline  []WorkerSample // with Config.Autoscale, the worker count from the start and after each change
+    Phases          PhaseTimes     // where Duration went
+    Err             error          // invalid configuration, or first ErrWorkerLost failure
+}
+
+// PhaseTimes splits the wall time of a search into its phases. Dispatching
+// and merging run alongside the workers, so Dispatch and Merge overlap
+// Compute.
+type PhaseTimes struct {
+    Planning time.Duration // validation, base primes and the chunk plan
+    Dispatch time.Duration // handing chunks to the worker queues, not counting waits for a free slot or a busy worker
+    Compute  time.Duration // from starting the workers until the last one finishes
+    Merge    time.Duration // merging chunk results and joining their primes
+}
+
+// searchUntil searches [start, end] with the configured algorithm, giving up
+// once ctx is cancelled or the deadline passes; a zero deadline never expires.
+// Testing algorithms step through the configured candidates only; the sieve
+// marks the whole range and keeps the matching primes.
+func (cfg Config) searchUntil(ctx context.Context, start, end int, deadline time.Time) ([]int, bool) {
+    switch cfg.Algorithm {
+    case AlgorithmSieve:
+        primes, ok := sieveRangeUntil(ctx, start, end, cfg.basePrimes, wheels[cfg.Wheel], deadline)
+        return keepCandidates(primes, cfg.Stride, cfg.Offset), ok
+    case AlgorithmMillerRabin:
+        return testRangeUntil(ctx, firstCandidate(start, cfg.Stride, cfg.Offset), end, cfg.Stride, mrTest(cfg.MRRounds, cfg.rng), deadline)
+    }
+    if deadline.IsZero() && ctx.Done() == nil && cfg.Stride <= 1 && cfg.Wheel == 0 {
+        return FindRange(start, end), true
+    }
+    return testRangeUntil(ctx, firstCandidate(start, cfg.Stride, cfg.Offset), end, cfg.Stride, cfg.trialTest(), deadline)
+}
+
+// countUntil is searchUntil that only counts the primes found
+func (cfg Config) countUntil(ctx context.Context, start, end int, deadline time.Time) (int, bool) {
+    switch cfg.Algorithm {
+    case AlgorithmSieve:
+        return sieveCountUntil(ctx, start, end, cfg.basePrimes, wheels[cfg.Wheel], cfg.Stride, cfg.Offset, deadline)
+    case AlgorithmMillerRabin:
+        return countRangeUntil(ctx, firstCandidate(start, cfg.Stride, cfg.Offset), end, cfg.Stride, mrTest(cfg.MRRounds, cfg.rng), deadline)
+    }
+    return countRangeUntil(ctx, firstCandidate(start, cfg.Stride, cfg.Offset), end, cfg.Stride, cfg.trialTest(), deadline)
+}
+
+// trialTest returns the trial division test for the configured wheel
+func (cfg Config) trialTest() func(int) bool {
+    if w := wheels[cfg.Wheel]; w != nil {
+        return w.isPrime
+    }
+    return IsPrime
+}
+
+// processChunk searches one chunk, retrying it when it exceeds the configured
+// timeout and quarantining it once the retries are used up. A chunk
+// interrupted by cancellation is not retried.
+func processChunk(ctx context.Context, job chunk, cfg Config) chunkResult {
+    attempts := cfg.ChunkRetries + 1
+    if cfg.ChunkTimeout <= 0 {
+        attempts = 1
+    }
+    
+    if cfg.Deterministic {
+        cfg.rng = rand.New(rand.NewPCG(cfg.Seed, uint64(job.start)))
+    }
+    for attempt := 0; attempt < attempts; attempt++ {
+        var deadline time.Time
+        if cfg.ChunkTimeout > 0 {
+            deadline = time.Now().Add(cfg.ChunkTimeout)
+        }
+        if cfg.CountOnly {
+            if count, ok := cfg.countUntil(ctx, job.start, job.end, deadline); ok {
+                return chunkResult{chunk: job, count: count, attempts: attempt + 1}
+            }
+        } else if primes, ok := cfg.searchUntil(ctx, job.start, job.end, deadline); ok {
+            return chunkResult{chunk: job, primes: primes, attempts: attempt + 1}
+        }
+        if ctx.Err() != nil {
+            return chunkResult{chunk: job, cancelled: true, attempts: attempt + 1}
+        }
+    }
+    return chunkResult{chunk: job, quarantined: true, attempts: attempts}
+}
+
+// crew is the set of workers of a search. With Config.Autoscale it grows
+// and shrinks while the search runs.
+type crew struct {
+    ctx      context.Context
+    results  chan<- chunkResult
+    cfg      Config
+    stats    []WorkerStats // indexed by worker id, sized for the most workers at once
+    wg       sync.WaitGroup
+    searched atomic.Int64  // numbers in the chunks searched so far
+    chunks   atomic.Int64  // chunks searched so far
+    retire   chan struct{} // each value asks one worker to exit
+    free     chan int      // ids of retired workers, for reuse
+    next     int           // lowest id not yet used
+}
+
+// newCrew prepares a crew of up to size workers; none is started
+func newCrew(ctx context.Context, results chan<- chunkResult, cfg Config, size int) *crew {
+    return &crew{
+        ctx:     ctx,
+        results: results,
+        cfg:     cfg,
+        stats:   make([]WorkerStats, size),
+        retire:  make(chan struct{}, size),
+        free:    make(chan int, size),
+    }
+}
+
+// start starts a worker taking chunks from jobs, reusing the id of a
+// retired worker once every id has been used. With no id free, as when a
+// retiring worker has yet to hand its id back, no worker is started and it
+// returns false.
+func (c *crew) start(jobs <-chan chunk) bool {
+    var id int
+    if c.next < len(c.stats) {
+        id = c.next
+        c.next++
+    } else {
+        select {
+        case id = <-c.free:
+        default:
+            return false
+        }
+    }
+    c.wg.Add(1)
+    go c.work(id, jobs)
+    return true
+}
+
+// grow adds a worker taking chunks from jobs. A worker asked to retire that
+// has not yet done so is kept instead of starting another. It reports
+// whether the crew grew either way.
+func (c *crew) grow(jobs <-chan chunk) bool {
+    select {
+    case <-c.retire:
+        return true
+    default:
+        return c.start(jobs)
+    }
+}
+
+// shrink asks one worker to exit once it finishes its current chunk
+func (c *crew) shrink() {
+    c.retire <- struct{}{}
+}
+
+// work processes chunks from jobs until it is closed or the worker is asked
+// to retire. A panic while processing a chunk is reported as a lost chunk
+// instead of taking down the process. With Config.LockThreads the worker
+// keeps its OS thread to itself for the whole search.
+func (c *crew) work(id int, jobs <-chan chunk) {
+    defer c.wg.Done()
+    
+    if c.cfg.LockThreads {
+        runtime.LockOSThread()
+        defer runtime.UnlockOSThread()
+    }
+    
+    for {
+        select {
+        case job, ok := <-jobs:
+            if !ok {
+                return
+            }
+            result := runChunk(c.ctx, id, job, c.cfg, &c.stats[id])
+            c.searched.Add(int64(job.end - job.start + 1))
+            c.chunks.Add(1)
+            c.results <- result
+        case <-c.retire:
+            c.free <- id
+            return
+        }
+    }
+}
+
+// runChunk searches one chunk for worker id as an execution-trace task,
+// timing it and counting it in stats
+func runChunk(ctx context.Context, id int, job chunk, cfg Config, stats *WorkerStats) chunkResult {
+    chunkCtx, task := trace.NewTask(ctx, "chunk")
+    defer task.End()
+    trace.Logf(chunkCtx, "chunk", "seq=%d range=[%d, %d] worker=%d", job.seq, job.start, job.end, id)
+    var result chunkResult
+    chunkStart := time.Now()
+    trace.WithRegion(chunkCtx, "search", func() {
+        result = safeProcessChunk(chunkCtx, job, cfg)
+    })
+    result.elapsed = time.Since(chunkStart)
+    stats.Chunks++
+    stats.Busy += result.elapsed
+    return result
+}
+
+// safeProcessChunk runs processChunk, converting a panic into a lost result
+func safeProcessChunk(ctx context.Context, job chunk, cfg Config) (result chunkResult) {
+    defer func() {
+        if r := recover(); r != nil {
+            result = chunkResult{chunk: job, lost: fmt.Errorf("%w: chunk [%d, %d]: %v", ErrWorkerLost, job.start, job.end, r)}
+        }
+    }()
+    return processChunk(ctx, job, cfg)
+}
+
+// FindRangeConfig finds the primes in [start, end] sequentially with the
+// algorithm and candidate set of cfg, in ascending order. Settings for
+// concurrent searches are ignored.
+func FindRangeConfig(start, end int, cfg Config) ([]int, error) {
+    algorithm, err := ResolveAlgorithm(cfg.Algorithm, end)
+    if err != nil {
+        return nil, err
+    }
+    if err := ValidateStride(cfg.Stride, cfg.Offset); err != nil {
+        return nil, err
+    }
+    if err := ValidateWheel(cfg.Wheel); err != nil {
+        return nil, err
+    }
+    cfg.Algorithm = algorithm
+    if algorithm == AlgorithmSieve {
+        cfg.basePrimes = sievingPrimes(isqrt(end))
+    }
+    if cfg.Deterministic {
+        cfg.rng = rand.New(rand.NewPCG(cfg.Seed, uint64(start)))
+    }
+    primes, _ := cfg.searchUntil(context.Background(), start, end, time.Time{})
+    return primes, nil
+}
+
+// CountRangeConfig is FindRangeConfig that counts the primes without
+// storing them
+func CountRangeConfig(start, end int, cfg Config) (int, error) {
+    algorithm, err := ResolveAlgorithm(cfg.Algorithm, end)
+    if err != nil {
+        return 0, err
+    }
+    if err := ValidateStride(cfg.Stride, cfg.Offset); err != nil {
+        return 0, err
+    }
+    if err := ValidateWheel(cfg.Wheel); err != nil {
+        return 0, err
+    }
+    cfg.Algorithm = algorithm
+    if algorithm == AlgorithmSieve {
+        cfg.basePrimes = sievingPrimes(isqrt(end))
+    }
+    if cfg.Deterministic {
+        cfg.rng = rand.New(rand.NewPCG(cfg.Seed, uint64(start)))
+    }
+    count, _ := cfg.countUntil(context.Background(), start, end, time.Time{})
+    return count, nil
+}
+
+// FindRangeConcurrent finds primes in [start, end] using concurrent
+// workers. Primes are returned in ascending order.
+func FindRangeConcurrent(start, end, workers int) ([]int, time.Duration) {
+    result := FindRangeConcurrentConfig(start, end, workers, Config{})
+    return result.Primes, result.Duration
+}
+
+// FindRangeConcurrentConfig is FindRangeConcurrent with optional tuning.
+// Quarantined chunks are left out of the primes and listed in the result.
+func FindRangeConcurrentConfig(start, end, workers int, cfg Config) SearchResult {
+    return FindRangeConcurrentContext(context.Background(), start, end, workers, cfg)
+}
+
+// FindRangeConcurrentContext is FindRangeConcurrentConfig that stops when
+// ctx is cancelled. Workers check for cancellation while searching; the
+// result then holds the primes of the chunks that completed, is marked
+// Cancelled, and lists the chunks that were not fully searched.
+func FindRangeConcurrentContext(parent context.Context, start, end, workers int, cfg Config) SearchResult {
+    return search(parent, start, end, workers, cfg, nil)
+}
+
+// search runs a concurrent search. If sink is nil the primes are collected
+// into the result; otherwise each chunk's primes (or what Config.Pipeline
+// keeps of them) are passed to sink in range order and the result's Primes
+// is left empty.
+func search(parent context.Context, start, end, workers int, cfg Config, sink func([]int)) SearchResult {
+    startTime := time.Now()
+    
+    algorithm, err := ResolveAlgorithm(cfg.Algorithm, end)
+    if err != nil {
+        return SearchResult{Err: err}
+    }
+    cfg.Algorithm = algorithm
+    if err := ValidateStride(cfg.Stride, cfg.Offset); err != nil {
+        return SearchResult{Err: err}
+    }
+    if err := ValidateWheel(cfg.Wheel); err != nil {
+        return SearchResult{Err: err}
+    }
+    if err := ValidateDeterministic(cfg); err != nil {
+        return SearchResult{Err: err}
+    }
+    if cfg.Limit < 0 || cfg.Limit > 0 && (cfg.Unordered || cfg.CountOnly) {
+        return SearchResult{Err: fmt.Errorf("%w: limit %d needs a non-negative count, range-ordered merging and stored primes", ErrInvalidArgument, cfg.Limit)}
+    }
+    if len(cfg.Pipeline) > 0 && (cfg.Unordered || cfg.Descending || cfg.CountOnly) {
+        return SearchResult{Err: fmt.Errorf("%w: a transform pipeline needs stored primes merged in ascending range order", ErrInvalidArgument)}
+    }
+    if cfg.Limit > 0 {
+        // Size chunks so the first round across all workers is expected to
+        // hold the limit, by the density of primes near end
+        cfg.MaxChunk = limitChunk(cfg.Limit, end, workers, cfg.MaxChunk)
+    }
+    if algorithm == AlgorithmSieve {
+        cfg.basePrimes = sievingPrimes(isqrt(end))
+    }
+    
+    ctx, task := trace.NewTask(parent, "findPrimes")
+    defer task.End()
+    trace.Logf(ctx, "job", "range=[%d, %d] workers=%d algorithm=%s", start, end, workers, algorithm)
+    if !cfg.SoftDeadline.IsZero() {
+        var cancel context.CancelFunc
+        ctx, cancel = context.WithDeadline(ctx, cfg.SoftDeadline)
+        defer cancel()
+    }
+    ctx, stop := context.WithCancel(ctx)
+    defer stop()
+    
+    // An autoscaled search may run up to twice its starting workers, and is
+    // planned for that many
+    size := workers
+    if cfg.Autoscale {
+        size = autoscaleFactor * workers
+    }
+    
+    // Chunks are cut by estimated cost where that varies across the range
+    cost := costModels[algorithm]
+    if cost != nil && cfg.Descending {
+        cost = reflectCost(cost, start, end)
+    }
+    plan, err := planChunks(start, end, size, cfg.Scheduler, cfg.MaxChunk, cost)
+    if err != nil {
+        return SearchResult{Err: err}
+    }
+    
+    // A descending search runs the same plan reflected about the middle of
+    // the range; coverage is tracked in the reflected order
+    mirror := func(n int) int { return n }
+    if cfg.Descending {
+        mirror = func(n int) int { return start + (end - n) }
+        for i, c := range plan {
+            plan[i].start, plan[i].end = mirror(c.end), mirror(c.start)
+        }
+    }
+    progress := Progress{Chunks: len(plan), Width: end - start + 1, Covered: mirror(start - 1)}
+    covered := coverage{end: start - 1}
+    
+    // Search rate of the latest chunk in numbers per second, as float64
+    // bits, and the number of extra chunks made by splitting for the soft
+    // deadline
+    var rate atomic.Uint64
+    var splits atomic.Int64
+    
+    // Workers share one queue and take chunks as they become free, except
+    // in a deterministic search, where each has its own
+    jobs := make(chan chunk, size)
+    queues := []chan chunk{jobs}
+    if cfg.Deterministic {
+        queues = make([]chan chunk, workers)
+        for i := range queues {
+            queues[i] = make(chan chunk, 1)
+        }
+    }
+    results := make(chan chunkResult, size)
+    
+    // Bound the number of chunks dispatched but not yet merged so memory
+    // stays O(workers) regardless of how the range is split
+    inFlight := make(chan struct{}, 2*size)
+    
+    // Start workers
+    var phases PhaseTimes
+    computeStart := time.Now()
+    phases.Planning = computeStart.Sub(startTime)
+    team := newCrew(ctx, results, cfg, size)
+    for i := 0; i < workers; i++ {
+        team.start(queues[i%len(queues)])
+    }
+    
+    // Send jobs until the range is covered or the search is cancelled. Ahead
+    // of a soft deadline, chunks are split so each is expected to finish in
+    // time: a chunk cut off by the deadline would leave a hole in the
+    // searched prefix.
+    var skipped []chunk
+    dispatched := make(chan struct{})
+    go func() {
+        defer close(dispatched)
+        defer trace.StartRegion(ctx, "dispatch").End()
+        defer func() {
+            for _, q := range queues {
+                close(q)
+            }
+        }()
+        prev := minDynamicChunk
+        for seq, queue := 0, plan; len(queue) > 0; seq++ {
+            job := queue[0]
+            if ctx.Err() == nil {
+                select {
+                case inFlight <- struct{}{}:
+                case <-ctx.Done():
+                }
+            }
+            if ctx.Err() != nil {
+                skipped = queue
+                return
+            }
+            sendStart := time.Now()
+            size := 0
+            if !cfg.SoftDeadline.IsZero() {
+                size = deadlineChunk(math.Float64frombits(rate.Load()), time.Until(cfg.SoftDeadline), prev)
+            }
+            if size > 0 && size <= job.end-job.start {
+                if cfg.Descending {
+                    job.start = job.end - size + 1
+                    queue[0].end = job.start - 1
+                } else {
+                    job.end = job.start + size - 1
+                    queue[0].start = job.end + 1
+                }
+                splits.Add(1)
+            } else {
+                queue = queue[1:]
+            }
+            prev = job.end - job.start + 1
+            job.seq = seq
+            // A full queue means every worker is busy; waiting for one is
+            // not dispatch cost
+            select {
+            case queues[seq%len(queues)] <- job:
+                phases.Dispatch += time.Since(sendStart)
+            default:
+                phases.Dispatch += time.Since(sendStart)
+                queues[seq%len(queues)] <- job
+            }
+        }
+    }()
+    
+    // The autoscaler counts as a worker, so the crew cannot finish while
+    // it may still add one
+    var timeline []WorkerSample
+    if cfg.Autoscale {
+        team.wg.Add(1)
+        go func() {
+            defer team.wg.Done()
+            timeline = autoscale(ctx, dispatched, team, jobs, newAutoscaler(workers, size), computeStart)
+        }()
+    }
+    
+    // Wait for workers to complete
+    go func() {
+        team.wg.Wait()
+        phases.Compute = time.Since(computeStart)
+        close(results)
+    }()
+    
+    // Merge results in range order. The collector takes ownership of each
+    // worker's buffer rather than appending its contents to a growing slice;
+    // buffers are joined once at the end into an exactly sized result, or
+    // handed to the sink as they arrive. A pipeline sees each chunk's primes
+    // as they are merged and passes on what it keeps.
+    result := SearchResult{Algorithm: algorithm}
+    var buffers [][]int
+    total, kept := 0, 0
+    emit := func(primes []int) {
+        if sink != nil {
+            sink(primes)
+        } else if !cfg.CountOnly {
+            buffers = append(buffers, primes)
+            kept += len(primes)
+        }
+    }
+    collect := trace.StartRegion(ctx, "collect")
+    merge := mergeChunks
+    if cfg.Unordered {
+        merge = takeChunks
+    }
+    merge(results, func(r chunkResult) {
+        mergeStart := time.Now()
+        defer func() { phases.Merge += time.Since(mergeStart) }()
+        if result.LimitReached {
+            // Chunks past the limit are not needed
+            return
+        }
+        if cfg.Descending {
+            slices.Reverse(r.primes)
+        }
+        if cfg.Limit > 0 && total+len(r.primes) >= cfg.Limit {
+            r.primes = r.primes[:cfg.Limit-total]
+            if last := r.primes[len(r.primes)-1]; cfg.Descending {
+                r.start = last
+            } else {
+                r.end = last
+            }
+            result.LimitReached = true
+            stop()
+        }
+        found := len(r.primes)
+        if cfg.CountOnly {
+            found = r.count
+        }
+        if r.lost != nil && result.Err == nil {
+            result.Err = r.lost
+        }
+        if r.quarantined {
+            result.Quarantined = append(result.Quarantined, [2]int{r.start, r.end})
+        }
+        if r.cancelled {
+            result.Unsearched = appendRange(result.Unsearched, r.start, r.end)
+        }
+        if len(cfg.Pipeline) > 0 {
+            emit(cfg.Pipeline.Apply(r.primes))
+        } else {
+            emit(r.primes)
+        }
+        total += found
+        result.ChunkCosts = append(result.ChunkCosts, ChunkCost{r.start, r.end, r.elapsed.Seconds()})
+        if !r.cancelled && !r.quarantined && r.lost == nil {
+            covered.add(min(mirror(r.start), mirror(r.end)), max(mirror(r.start), mirror(r.end)), found)
+            rate.Store(math.Float64bits(float64(r.end-r.start+1) / r.elapsed.Seconds()))
+        }
+    
+        if cfg.OnProgress != nil {
+            progress.ChunksDone++
+            progress.Chunks = len(plan) + int(splits.Load())
+            if !r.cancelled {
+                progress.Searched += r.end - r.start + 1
+            }
+            progress.Primes = total
+            progress.Covered, progress.CoveredPrimes = mirror(covered.end), covered.primes
+            progress.Elapsed = time.Since(startTime)
+            progress.Last = ChunkReport{Start: r.start, End: r.end, Primes: found, Seconds: r.elapsed.Seconds(), Attempts: r.attempts, Status: r.status()}
+            cfg.OnProgress(progress)
+        }
+    }, func() {
+        <-inFlight
+    })
+    if flushed := cfg.Pipeline.Flush(); len(flushed) > 0 {
+        emit(flushed)
+    }
+    if sink == nil && !cfg.CountOnly {
+        joinStart := time.Now()
+        result.Primes = joinBuffers(buffers, kept)
+        phases.Merge += time.Since(joinStart)
+    }
+    result.Count = total
+    collect.End()
+    
+    <-dispatched
+    if !result.LimitReached {
+        for _, c := range skipped {
+            result.Unsearched = append(result.Unsearched, [2]int{c.start, c.end})
+        }
+    }
+    result.Unsearched = mergeRanges(result.Unsearched)
+    result.Covered, result.CoveredPrimes = mirror(covered.end), covered.primes
+    result.DeadlineReached = len(result.Unsearched) > 0 && parent.Err() == nil && !cfg.SoftDeadline.IsZero()
+    result.Cancelled = len(result.Unsearched) > 0 && !result.DeadlineReached
+    
+    result.Duration = time.Since(startTime)
+    stats := team.stats[:team.next]
+    for i := range stats {
+        stats[i].Utilization = stats[i].Busy.Seconds() / result.Duration.Seconds()
+    }
+    result.Workers = stats
+    result.WorkerTimeline = timeline
+    result.Phases = phases
+    return result
+}
+
+// appendRange appends [start, end] to ascending ranges, extending the last
+// one if the two are adjacent
+func appendRange(ranges [][2]int, start, end int) [][2]int {
+    if n := len(ranges); n > 0 && ranges[n-1][1]+1 == start {
+        ranges[n-1][1] = end
+        return ranges
+    }
+    return append(ranges, [2]int{start, end})
+}
+
+// mergeRanges sorts ranges and joins adjacent ones
+func mergeRanges(ranges [][2]int) [][2]int {
+    sort.Slice(ranges, func(i, j int) bool { return ranges[i][0] < ranges[j][0] })
+    var merged [][2]int
+    for _, r := range ranges {
+        merged = appendRange(merged, r[0], r[1])
+    }
+    return merged
+}
+
+// joinBuffers concatenates ordered chunk buffers into one slice of length
+// total. A single buffer is handed back as is.
+func joinBuffers(buffers [][]int, total int) []int {
+    if len(buffers) == 1 {
+        return buffers[0]
+    }
+    if total == 0 {
+        return nil
+    }
+    primes := make([]int, 0, total)
+    for _, buf := range buffers {
+        primes = append(primes, buf...)
+    }
+    return primes
+}
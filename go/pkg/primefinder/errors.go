@@ -0,0 +1,28 @@
+// errors.go
+package primefinder
+
+import (
+    "errors"
+    "fmt"
+)
+
+// Error kinds returned by the package and shared with the CLI. They are
+// wrapped with %w, so classify failures with errors.Is.
+var (
+    ErrInvalidArgument = errors.New("invalid argument")
+    ErrInvalidRange    = errors.New("invalid range")
+    ErrSinkWrite       = errors.New("cannot write output")
+    ErrWorkerLost      = errors.New("worker lost")
+    ErrCancelled       = errors.New("search cancelled")
+)
+
+// ValidateRange checks the range and worker count shared by every search
+func ValidateRange(start, end, workers int) error {
+    if start > end {
+        return fmt.Errorf("%w: start %d is greater than end %d", ErrInvalidRange, start, end)
+    }
+    if workers < 1 {
+        return fmt.Errorf("%w: workers must be at least 1, got %d", ErrInvalidArgument, workers)
+    }
+    return nil
+}
@@ -0,0 +1,19 @@
+// errors_test.go
+package primefinder
+
+import (
+    "errors"
+    "testing"
+)
+
+func TestValidateRange(t *testing.T) {
+    if err := ValidateRange(1, 10, 1); err != nil {
+        t.Errorf("Unexpected error: %v", err)
+    }
+    if err := ValidateRange(10, 1, 1); !errors.Is(err, ErrInvalidRange) {
+        t.Errorf("Expected ErrInvalidRange, got %v", err)
+    }
+    if err := ValidateRange(1, 10, 0); !errors.Is(err, ErrInvalidArgument) {
+        t.Errorf("Expected ErrInvalidArgument, got %v", err)
+    }
+}
@@ -0,0 +1,55 @@
+// estimate.go
+package primefinder
+
+import (
+    "math"
+    "time"
+)
+
+// trialDivisionsPerSecond is roughly how many trial divisions one core
+// makes per second, measured near 10^12, 10^14 and 10^16
+const trialDivisionsPerSecond = 2e8
+
+// TrialEstimate is a rough forecast of how long trial division of a range
+// takes
+type TrialEstimate struct {
+    Total    time.Duration // the whole range, spread over the workers
+    PerPrime time.Duration // one prime at the end of the range
+}
+
+// trialDivisions returns the number of divisions trial division makes to
+// prove n prime with the given wheel: one per cached prime up to sqrt(n),
+// then one per number past the cache the wheel does not skip
+func trialDivisions(n float64, w *wheel) float64 {
+    root := math.Sqrt(n)
+    cached := min(root, float64(CacheLimit()))
+    divisions := cached / math.Log(max(cached, 3))
+    if root > cached {
+        divisions += (root - cached) * float64(len(w.residues)) / float64(w.size)
+    }
+    return divisions
+}
+
+// EstimateTrialDivision forecasts trial division of every stride-th number
+// of [start, end] with the given workers and Config.Wheel size. Most
+// composites fall to a small divisor, so the cost is dominated by the
+// primes, each of which is divided by every candidate up to its square
+// root; pricing them all at the end of the range makes this an upper
+// estimate within a small factor.
+func EstimateTrialDivision(start, end, stride, workers, wheelSize int) TrialEstimate {
+    if end < max(start, 2) {
+        return TrialEstimate{}
+    }
+    w := wheels[wheelSize]
+    if w == nil {
+        w = wheel6
+    }
+    x := float64(end)
+    candidates := float64(end-max(start, 2)+1) / float64(max(stride, 1))
+    perPrime := trialDivisions(x, w)
+    total := candidates * (1 + perPrime/math.Log(max(x, 3)))
+    seconds := func(divisions float64) time.Duration {
+        return time.Duration(divisions / trialDivisionsPerSecond * float64(time.Second))
+    }
+    return TrialEstimate{Total: seconds(total / float64(max(workers, 1))), PerPrime: seconds(perPrime)}
+}
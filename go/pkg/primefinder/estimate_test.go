@@ -0,0 +1,39 @@
+// estimate_test.go
+package primefinder
+
+import (
+    "testing"
+    "time"
+)
+
+func TestEstimateTrialDivision(t *testing.T) {
+    tests := []struct {
+        name               string
+        start, end         int
+        stride, workers    int
+        wheel              int
+        minTotal, maxTotal time.Duration
+    }{
+        {"small range", 1, 100_000, 0, 1, 0, 0, 10 * time.Millisecond},
+        {"20000 past 10^12", 1e12, 1e12 + 20_000, 0, 1, 0, 100 * time.Millisecond, time.Second},
+        {"20000 past 10^16", 1e16, 1e16 + 20_000, 0, 1, 0, 30 * time.Second, 10 * time.Minute},
+        {"more workers", 1e16, 1e16 + 20_000, 0, 8, 0, 4 * time.Second, 2 * time.Minute},
+        {"billion past 10^18", 1e18, 1e18 + 1e9, 0, 4, 0, 24 * time.Hour, 10000 * time.Hour},
+        {"empty", 10, 5, 0, 1, 0, 0, 0},
+    }
+    for _, tt := range tests {
+        got := EstimateTrialDivision(tt.start, tt.end, tt.stride, tt.workers, tt.wheel)
+        if got.Total < tt.minTotal || got.Total > tt.maxTotal {
+            t.Errorf("%s: estimated %v, expected between %v and %v", tt.name, got.Total, tt.minTotal, tt.maxTotal)
+        }
+    }
+    
+    // A larger wheel and a stride both cut the estimate
+    base := EstimateTrialDivision(1e16, 1e16+1e6, 0, 1, 0)
+    if wheel := EstimateTrialDivision(1e16, 1e16+1e6, 0, 1, 210); wheel.PerPrime >= base.PerPrime {
+        t.Errorf("wheel 210 per prime %v, not below %v", wheel.PerPrime, base.PerPrime)
+    }
+    if strided := EstimateTrialDivision(1e16, 1e16+1e6, 4, 1, 0); strided.Total >= base.Total {
+        t.Errorf("stride 4 total %v, not below %v", strided.Total, base.Total)
+    }
+}
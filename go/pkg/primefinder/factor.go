@@ -0,0 +1,127 @@
+// factor.go
+package primefinder
+
+import (
+    "math/bits"
+    "slices"
+)
+
+// factorTrialLimit bounds the cached primes Factorize divides by before
+// handing the cofactor to Pollard's rho. Once they are divided out, any
+// cofactor below factorTrialLimit^2 is prime.
+const factorTrialLimit = 1 << 16
+
+// Factor is a prime factor and the power it divides a number to
+type Factor struct {
+    Prime    uint64 `json:"prime"`
+    Exponent int    `json:"exponent"`
+}
+
+// Factorize returns the prime factorization of n in ascending order of
+// primes, empty for 0 and 1. Small primes are divided out by trial division
+// with the cached base primes; what remains is split with Pollard's rho
+// (Brent's variant) until every part passes the exact Miller-Rabin test.
+func Factorize(n uint64) []Factor {
+    factors := []Factor{}
+    if n < 2 {
+        return factors
+    }
+    for _, p := range sievingPrimes(factorTrialLimit) {
+        q := uint64(p)
+        if q*q > n {
+            break
+        }
+        if n%q != 0 {
+            continue
+        }
+        f := Factor{Prime: q}
+        for n%q == 0 {
+            n /= q
+            f.Exponent++
+        }
+        factors = append(factors, f)
+    }
+    if n == 1 {
+        return factors
+    }
+    
+    var large []uint64
+    splitFactor(n, &large)
+    slices.Sort(large)
+    for _, p := range large {
+        if last := len(factors) - 1; last >= 0 && factors[last].Prime == p {
+            factors[last].Exponent++
+        } else {
+            factors = append(factors, Factor{Prime: p, Exponent: 1})
+        }
+    }
+    return factors
+}
+
+// splitFactor appends the prime factors of n, which has no factor below
+// factorTrialLimit, to primes, with repetition
+func splitFactor(n uint64, primes *[]uint64) {
+    if n < factorTrialLimit*factorTrialLimit || IsPrimeMR(n) {
+        *primes = append(*primes, n)
+        return
+    }
+    d := pollardRho(n)
+    splitFactor(d, primes)
+    splitFactor(n/d, primes)
+}
+
+// pollardRho returns a nontrivial divisor of the odd composite n using
+// Brent's cycle detection on x -> x^2 + c mod n, trying c = 1, 2, ... until
+// one splits n
+func pollardRho(n uint64) uint64 {
+    const batch = 128 // differences multiplied together before each gcd
+    for c := uint64(1); ; c++ {
+        f := func(x uint64) uint64 {
+            hi, lo := bits.Mul64(x, x)
+            lo, carry := bits.Add64(lo, c, 0)
+            return bits.Rem64(hi+carry, lo, n)
+        }
+        var x, ys uint64
+        y, q, g := uint64(2), uint64(1), uint64(1)
+        for r := 1; g == 1; r *= 2 {
+            x = y
+            for i := 0; i < r; i++ {
+                y = f(y)
+            }
+            for k := 0; k < r && g == 1; k += batch {
+                ys = y
+                for i := 0; i < min(batch, r-k); i++ {
+                    y = f(y)
+                    q = mulMod(q, absDiff(x, y), n)
+                }
+                g = gcd(q, n)
+            }
+        }
+        if g == n {
+            // The batch overshot; step through it one difference at a time
+            for g = 1; g == 1; {
+                ys = f(ys)
+                g = gcd(absDiff(x, ys), n)
+            }
+        }
+        if g != n {
+            return g
+        }
+    }
+}
+
+// absDiff returns |a - b|
+func absDiff(a, b uint64) uint64 {
+    if a > b {
+        return a - b
+    }
+    return b - a
+}
+
+// gcd returns the greatest common divisor of a and b
+func gcd(a, b uint64) uint64 {
+    for b != 0 {
+        a, b = b, a%b
+    }
+    return a
+}
@@ -0,0 +1,48 @@
+// factor_test.go
+package primefinder
+
+import (
+    "math"
+    "reflect"
+    "testing"
+)
+
+func TestFactorize(t *testing.T) {
+    tests := []struct {
+        n        uint64
+        expected []Factor
+    }{
+        {0, []Factor{}},
+        {1, []Factor{}},
+        {2, []Factor{{2, 1}}},
+        {360, []Factor{{2, 3}, {3, 2}, {5, 1}}},
+        {65537 * 65537, []Factor{{65537, 2}}},                                            // square just past the trial limit
+        {4294967291 * 4294967279, []Factor{{4294967279, 1}, {4294967291, 1}}},            // two 32-bit primes
+        {3825123056546413051, []Factor{{149491, 1}, {747451, 1}, {34233211, 1}}},         // strong pseudoprime to bases 2 through 23
+        {18446744073709551557, []Factor{{18446744073709551557, 1}}},                      // largest 64-bit prime
+        {math.MaxUint64, []Factor{{3, 1}, {5, 1}, {17, 1}, {257, 1}, {641, 1}, {65537, 1}, {6700417, 1}}},
+        {1 << 63, []Factor{{2, 63}}},
+        {999999000001 * 999983, []Factor{{999983, 1}, {999999000001, 1}}},
+    }
+    for _, tt := range tests {
+        if got := Factorize(tt.n); !reflect.DeepEqual(got, tt.expected) {
+            t.Errorf("Factorize(%d) = %v, expected %v", tt.n, got, tt.expected)
+        }
+    }
+    
+    // Every factorization multiplies back to n with prime factors
+    for n := uint64(2); n < 20000; n++ {
+        product := uint64(1)
+        for _, f := range Factorize(n) {
+            if !IsPrimeMR(f.Prime) {
+                t.Fatalf("Factorize(%d) has composite factor %d", n, f.Prime)
+            }
+            for i := 0; i < f.Exponent; i++ {
+                product *= f.Prime
+            }
+        }
+        if product != n {
+            t.Fatalf("Factorize(%d) multiplies to %d", n, product)
+        }
+    }
+}
@@ -0,0 +1,96 @@
+// family.go
+package primefinder
+
+import (
+    "context"
+    "sync"
+    "time"
+)
+
+// FamilyResult is the outcome of testing one member of a family of numbers
+// such as k*2^n+1, identified by its parameter X
+type FamilyResult struct {
+    X        int
+    Prime    bool
+    TimedOut bool // the test gave up after the per-member timeout
+    Elapsed  time.Duration
+    
+    cancelled bool // interrupted by cancellation; not tested
+}
+
+// familyTest tests the family member with parameter x, returning ok false if
+// it stopped because ctx is done
+type familyTest func(ctx context.Context, x int) (prime, ok bool)
+
+// scanFamily tests the members x in [lo, hi] of a family with concurrent
+// workers, each test limited to timeout when that is positive. Results are
+// passed to onTested in ascending x. It returns the end of the tested prefix,
+// which is hi unless ctx was cancelled first.
+func scanFamily(ctx context.Context, lo, hi, workers int, timeout time.Duration, test familyTest, onTested func(FamilyResult)) int {
+    jobs := make(chan int)
+    results := make(chan FamilyResult, workers)
+    
+    // Bound the results held back for ordering, as for range searches
+    inFlight := make(chan struct{}, 2*workers)
+    
+    go func() {
+        defer close(jobs)
+        for x := lo; x <= hi && ctx.Err() == nil; x++ {
+            select {
+            case inFlight <- struct{}{}:
+                jobs <- x
+            case <-ctx.Done():
+            }
+        }
+    }()
+    
+    var wg sync.WaitGroup
+    for i := 0; i < workers; i++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for x := range jobs {
+                testCtx, cancel := ctx, context.CancelFunc(func() {})
+                if timeout > 0 {
+                    testCtx, cancel = context.WithTimeout(ctx, timeout)
+                }
+                started := time.Now()
+                prime, ok := false, false
+                if ctx.Err() == nil {
+                    prime, ok = test(testCtx, x)
+                }
+                cancel()
+                result := FamilyResult{X: x, Prime: prime, Elapsed: time.Since(started)}
+                if !ok {
+                    result.Prime = false
+                    result.cancelled = ctx.Err() != nil
+                    result.TimedOut = !result.cancelled
+                }
+                results <- result
+            }
+        }()
+    }
+    go func() {
+        wg.Wait()
+        close(results)
+    }()
+    
+    // Emit in ascending order; a cancelled member ends the tested prefix.
+    // Results are still drained after that so the workers can finish.
+    pending := make(map[int]FamilyResult)
+    next, stopped := lo, false
+    for result := range results {
+        pending[result.X] = result
+        for r, ok := pending[next]; ok && !stopped; r, ok = pending[next] {
+            delete(pending, next)
+            if r.cancelled {
+                stopped = true
+                break
+            }
+            onTested(r)
+            next++
+            <-inFlight
+        }
+    }
+    return next - 1
+}
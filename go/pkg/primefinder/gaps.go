@@ -0,0 +1,96 @@
+// gaps.go
+package primefinder
+
+import (
+    "fmt"
+    "slices"
+)
+
+// GapReport summarizes the gaps between consecutive primes of a range
+type GapReport struct {
+    Gaps       int         `json:"gaps"`
+    MaxGap     int         `json:"max_gap"`
+    MaxGapAt   *[2]int     `json:"max_gap_at,omitempty"` // primes around the first maximal gap
+    AverageGap float64     `json:"average_gap"`
+    Histogram  []GapBucket `json:"histogram"`
+}
+
+// GapBucket counts the gaps of size From through To
+type GapBucket struct {
+    From  int `json:"from"`
+    To    int `json:"to"`
+    Count int `json:"count"`
+}
+
+// GapStats accumulates gaps between consecutive primes fed in ascending
+// order, one at a time or in batches such as the chunks of a search in range
+// order; the last prime is carried over, so gaps spanning batches count.
+type GapStats struct {
+    Bucket  int // histogram bucket width
+    gaps    int
+    sum     int
+    max     int
+    maxAt   int
+    buckets map[int]int // bucket -> gaps in it
+    last    int
+    started bool
+}
+
+// NewGapStats returns stats with a histogram of the given bucket width
+func NewGapStats(bucket int) (*GapStats, error) {
+    if bucket < 1 {
+        return nil, fmt.Errorf("%w: gap bucket width must be at least 1, got %d", ErrInvalidArgument, bucket)
+    }
+    return &GapStats{Bucket: bucket, buckets: map[int]int{}}, nil
+}
+
+// Next feeds the next prime
+func (g *GapStats) Next(p int) {
+    if g.started {
+        gap := p - g.last
+        g.gaps++
+        g.sum += gap
+        if gap > g.max {
+            g.max, g.maxAt = gap, g.last
+        }
+        g.buckets[gap/g.Bucket]++
+    }
+    g.last, g.started = p, true
+}
+
+// Add feeds a batch of primes
+func (g *GapStats) Add(batch []int) {
+    for _, p := range batch {
+        g.Next(p)
+    }
+}
+
+// Apply feeds a batch and passes it on unchanged, so the stats can watch a
+// Pipeline
+func (g *GapStats) Apply(batch []int) []int {
+    g.Add(batch)
+    return batch
+}
+
+// Flush has nothing to pass on
+func (g *GapStats) Flush() []int { return nil }
+
+// Report returns the statistics of the primes fed so far, with the
+// histogram in ascending order of gap size
+func (g *GapStats) Report() GapReport {
+    report := GapReport{Gaps: g.gaps, MaxGap: g.max, Histogram: []GapBucket{}}
+    if g.gaps == 0 {
+        return report
+    }
+    report.MaxGapAt = &[2]int{g.maxAt, g.maxAt + g.max}
+    report.AverageGap = float64(g.sum) / float64(g.gaps)
+    keys := make([]int, 0, len(g.buckets))
+    for k := range g.buckets {
+        keys = append(keys, k)
+    }
+    slices.Sort(keys)
+    for _, k := range keys {
+        report.Histogram = append(report.Histogram, GapBucket{From: k * g.Bucket, To: (k+1)*g.Bucket - 1, Count: g.buckets[k]})
+    }
+    return report
+}
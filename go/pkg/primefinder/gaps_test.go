@@ -0,0 +1,50 @@
+// gaps_test.go
+package primefinder
+
+import (
+    "errors"
+    "reflect"
+    "testing"
+)
+
+func TestGapStats(t *testing.T) {
+    g, _ := NewGapStats(2)
+    g.Add(FindRange(1, 30)) // 2 3 5 7 11 13 17 19 23 29
+    expected := GapReport{
+        Gaps:       9,
+        MaxGap:     6,
+        MaxGapAt:   &[2]int{23, 29},
+        AverageGap: 27.0 / 9,
+        Histogram:  []GapBucket{{0, 1, 1}, {2, 3, 4}, {4, 5, 3}, {6, 7, 1}},
+    }
+    if got := g.Report(); !reflect.DeepEqual(got, expected) {
+        t.Errorf("Report() = %+v, expected %+v", got, expected)
+    }
+    
+    // Splitting the primes into batches anywhere gives the same report
+    primes := FindRange(1, 200000)
+    whole, _ := NewGapStats(10)
+    whole.Add(primes)
+    for _, size := range []int{1, 3, 1000} {
+        split, _ := NewGapStats(10)
+        for lo := 0; lo < len(primes); lo += size {
+            split.Add(primes[lo:min(lo+size, len(primes))])
+        }
+        if !reflect.DeepEqual(split.Report(), whole.Report()) {
+            t.Errorf("batches of %d: got %+v, expected %+v", size, split.Report(), whole.Report())
+        }
+    }
+    // The maximal gap below 2*10^5 is 86, after 155921
+    if r := whole.Report(); r.MaxGap != 86 || r.MaxGapAt[0] != 155921 {
+        t.Errorf("max gap %d at %v, expected 86 after 155921", r.MaxGap, r.MaxGapAt)
+    }
+    
+    empty, _ := NewGapStats(1)
+    empty.Next(7)
+    if r := empty.Report(); r.Gaps != 0 || r.MaxGapAt != nil || len(r.Histogram) != 0 {
+        t.Errorf("a single prime reported %+v", r)
+    }
+    if _, err := NewGapStats(0); !errors.Is(err, ErrInvalidArgument) {
+        t.Errorf("NewGapStats(0): got %v, expected ErrInvalidArgument", err)
+    }
+}
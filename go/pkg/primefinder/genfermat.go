@@ -0,0 +1,86 @@
+// genfermat.go
+package primefinder
+
+import (
+    "context"
+    "fmt"
+    "math/big"
+    "time"
+)
+
+// genFermatExpBits is the size of the power of b raised per big.Int.Exp call
+// in a generalized Fermat test; cancellation is checked between calls
+const genFermatExpBits = 256
+
+// GenFermatNumber returns b^(2^m)+1
+func GenFermatNumber(b, m int) *big.Int {
+    N := big.NewInt(int64(b))
+    for i := 0; i < m; i++ {
+        N.Mul(N, N)
+    }
+    return N.Add(N, big.NewInt(1))
+}
+
+// IsGenFermatPrime reports whether b^(2^m)+1 is a probable prime
+func IsGenFermatPrime(b, m int) bool {
+    prime, _ := genFermatTest(context.Background(), b, m)
+    return prime
+}
+
+// genFermatTest checks N = b^(2^m)+1 with a Fermat test to base 3, whose
+// exponent N-1 = b^(2^m) is reached by raising to b 2^m times, and confirms
+// a pass with Baillie-PSW. Numbers below 2^64 are decided exactly. ok is
+// false if ctx was done before the test finished.
+func genFermatTest(ctx context.Context, b, m int) (prime, ok bool) {
+    N := GenFermatNumber(b, m)
+    if N.BitLen() < 64 {
+        return N.ProbablyPrime(bigRounds), true
+    }
+    three := big.NewInt(3)
+    if N.Bit(0) == 0 || new(big.Int).Mod(N, three).Sign() == 0 {
+        return false, true
+    }
+    
+    // Raise to b^step per call, for step powers of b filling the block
+    step := max(genFermatExpBits/big.NewInt(int64(b)).BitLen(), 1)
+    power := new(big.Int).Exp(big.NewInt(int64(b)), big.NewInt(int64(step)), nil)
+    x := new(big.Int).Set(three)
+    for left := 1 << m; left > 0; left -= step {
+        if ctx.Err() != nil {
+            return false, false
+        }
+        if left < step {
+            power.Exp(big.NewInt(int64(b)), big.NewInt(int64(left)), nil)
+        }
+        x.Exp(x, power, N)
+    }
+    if x.Cmp(big.NewInt(1)) != 0 {
+        return false, true
+    }
+    return N.ProbablyPrime(0), true
+}
+
+// FindGenFermat tests b^(2^m)+1 for each b in [bMin, bMax] with concurrent
+// workers, giving up on a base after timeout when that is positive. Results
+// are passed to onTested in ascending b. It returns the last b of the tested
+// prefix, which is bMax unless ctx was cancelled, in which case the error
+// wraps ErrCancelled.
+func FindGenFermat(ctx context.Context, m, bMin, bMax, workers int, timeout time.Duration, onTested func(FamilyResult)) (int, error) {
+    if m < 0 || m > 30 {
+        return bMin - 1, fmt.Errorf("%w: m must be in [0, 30], got %d", ErrInvalidArgument, m)
+    }
+    if bMin < 1 || bMin > bMax {
+        return bMin - 1, fmt.Errorf("%w: need 1 <= b-min <= b-max, got [%d, %d]", ErrInvalidRange, bMin, bMax)
+    }
+    if workers < 1 {
+        return bMin - 1, fmt.Errorf("%w: workers must be at least 1, got %d", ErrInvalidArgument, workers)
+    }
+    
+    through := scanFamily(ctx, bMin, bMax, workers, timeout, func(ctx context.Context, b int) (bool, bool) {
+        return genFermatTest(ctx, b, m)
+    }, onTested)
+    if through < bMax {
+        return through, fmt.Errorf("%w: tested b up to %d of %d", ErrCancelled, through, bMax)
+    }
+    return through, nil
+}
@@ -0,0 +1,45 @@
+// genfermat_test.go
+package primefinder
+
+import (
+    "context"
+    "slices"
+    "testing"
+    "time"
+)
+
+func TestIsGenFermatPrime(t *testing.T) {
+    for _, m := range []int{0, 1, 3, 4, 5} {
+        for b := 1; b <= 300; b++ {
+            if got, expected := IsGenFermatPrime(b, m), GenFermatNumber(b, m).ProbablyPrime(bigRounds); got != expected {
+                t.Errorf("IsGenFermatPrime(%d, %d) = %v, expected %v", b, m, got, expected)
+            }
+        }
+    }
+}
+
+func TestFindGenFermat(t *testing.T) {
+    // b with b^4+1 prime (OEIS A000068)
+    expected := []int{1, 2, 4, 6, 16, 20, 24, 28, 34, 46, 48, 54, 56, 74, 80, 82, 88, 90, 106}
+    var found []int
+    through, err := FindGenFermat(context.Background(), 2, 1, 110, 4, 0, func(r FamilyResult) {
+        if r.Prime {
+            found = append(found, r.X)
+        }
+    })
+    if err != nil || through != 110 || !slices.Equal(found, expected) {
+        t.Errorf("FindGenFermat(2, 1, 110) = %d, %v with primes at %v, expected %v", through, err, found, expected)
+    }
+    
+    // A timeout no test can meet marks large candidates timed out, but they
+    // still count as tested
+    var timedOut int
+    through, err = FindGenFermat(context.Background(), 16, 2, 5, 2, time.Nanosecond, func(r FamilyResult) {
+        if r.TimedOut {
+            timedOut++
+        }
+    })
+    if err != nil || through != 5 || timedOut == 0 {
+        t.Errorf("timed-out scan = %d, %v with %d timeouts", through, err, timedOut)
+    }
+}
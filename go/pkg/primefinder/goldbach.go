@@ -0,0 +1,199 @@
+// goldbach.go
+package primefinder
+
+import (
+    "context"
+    "fmt"
+    "sync"
+    "time"
+)
+
+const (
+    // goldbachWindow bounds the least prime p with n-p prime that a chunk
+    // looks up in its sieved window. It is below 10^4 for every even n up to
+    // 4·10^18 (Oliveira e Silva, Herzog and Pardi), so the slower fallback
+    // past it is there for completeness only.
+    goldbachWindow = 10_000
+    // goldbachChunk is the number of even numbers verified per job
+    goldbachChunk = 1 << 15
+    // MaxGoldbachCountEnd bounds VerifyGoldbach with counts, which keeps a
+    // table of every prime up to the end of the range
+    MaxGoldbachCountEnd = 100_000_000
+)
+
+// GoldbachReport is the outcome of VerifyGoldbach. The least prime of a
+// decomposition n = p + q is its smaller member p.
+type GoldbachReport struct {
+    Checked         int                  `json:"checked"`         // even numbers verified
+    Counterexamples []int                `json:"counterexamples"` // even numbers that are not the sum of two primes
+    MaxLeast        int                  `json:"max_least_prime"` // largest least prime any n needed
+    MaxLeastAt      int                  `json:"max_least_prime_at,omitempty"`
+    Partitions      []GoldbachPartitions `json:"partitions,omitempty"` // with counts
+}
+
+// GoldbachPartitions counts the decompositions n = p + q with p <= q
+type GoldbachPartitions struct {
+    N     int `json:"n"`
+    Count int `json:"count"`
+    Least int `json:"least_prime"`
+}
+
+// goldbachResult is the part of a report one chunk of even numbers yields
+type goldbachResult struct {
+    report  GoldbachReport
+    skipped bool // cancelled before the chunk was verified
+}
+
+// VerifyGoldbach checks that every even number in [start, end] greater than
+// 2 is the sum of two primes, splitting the range into chunks verified by
+// concurrent workers. Each chunk sieves a window just below its numbers and
+// looks for the least prime p with n-p prime, drawing p and the sieving
+// primes from the shared base-prime cache. With counts it also counts every
+// decomposition of each n, which tests all primes up to n/2 against a table
+// of the primes to end, so end is limited to MaxGoldbachCountEnd.
+// Cancelling ctx returns ErrCancelled.
+func VerifyGoldbach(ctx context.Context, start, end, workers int, counts bool) (GoldbachReport, error) {
+    if err := ValidateRange(start, end, workers); err != nil {
+        return GoldbachReport{}, err
+    }
+    if end > maxSieveEnd || counts && end > MaxGoldbachCountEnd {
+        return GoldbachReport{}, fmt.Errorf("%w: Goldbach verification supports ranges up to %d (%d with counts)", ErrInvalidRange, maxSieveEnd, MaxGoldbachCountEnd)
+    }
+    first := max(start+start%2, 4)
+    if first > end {
+        return GoldbachReport{Counterexamples: []int{}}, nil
+    }
+    
+    var primes []int
+    var table []bool
+    if counts {
+        WarmCache(end)
+        primes = sievingPrimes(end)
+        table = make([]bool, end+1)
+        for _, p := range primes {
+            table[p] = true
+        }
+    }
+    small := sievingPrimes(goldbachWindow)
+    base := sievingPrimes(isqrt(end))
+    
+    chunks := (end-first)/(2*goldbachChunk) + 1
+    results := make([]goldbachResult, chunks)
+    jobs := make(chan int, workers)
+    var wg sync.WaitGroup
+    for w := 0; w < workers; w++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for i := range jobs {
+                lo := first + i*2*goldbachChunk
+                hi := min(lo+2*(goldbachChunk-1), end)
+                if ctx.Err() != nil {
+                    results[i].skipped = true
+                    continue
+                }
+                if counts {
+                    results[i].report = goldbachCounts(lo, hi, primes, table)
+                } else {
+                    results[i].report = goldbachWitnesses(lo, hi, small, base)
+                }
+            }
+        }()
+    }
+    for i := 0; i < chunks; i++ {
+        jobs <- i
+    }
+    close(jobs)
+    wg.Wait()
+    
+    report := GoldbachReport{Counterexamples: []int{}}
+    for _, r := range results {
+        if r.skipped {
+            return report, fmt.Errorf("%w: %d of %d even numbers verified", ErrCancelled, report.Checked, (end-first)/2+1)
+        }
+        report.Checked += r.report.Checked
+        report.Counterexamples = append(report.Counterexamples, r.report.Counterexamples...)
+        if r.report.MaxLeast > report.MaxLeast {
+            report.MaxLeast, report.MaxLeastAt = r.report.MaxLeast, r.report.MaxLeastAt
+        }
+        report.Partitions = append(report.Partitions, r.report.Partitions...)
+    }
+    return report, nil
+}
+
+// goldbachWitnesses verifies the even numbers in [lo, hi], lo >= 4, by
+// finding the least prime p of each from small, using a sieve of the
+// window [lo-goldbachWindow, hi] for n-p
+func goldbachWitnesses(lo, hi int, small, base []int) GoldbachReport {
+    wlo := max(lo-goldbachWindow, 2)
+    prime := make([]bool, hi-wlo+1)
+    sieveSegmentsUntil(context.Background(), wlo, hi, base, nil, time.Time{}, func(seg int, segment []bool) {
+        for i, c := range segment {
+            prime[seg-wlo+i] = !c
+        }
+    })
+    
+    report := GoldbachReport{}
+    for n := lo; n <= hi; n += 2 {
+        least := 0
+        for _, p := range small {
+            if p > n/2 {
+                break
+            }
+            if q := n - p; q >= wlo && prime[q-wlo] {
+                least = p
+                break
+            }
+        }
+        if least == 0 && n/2 > goldbachWindow {
+            least = goldbachFallback(n)
+        }
+        report.Checked++
+        if least == 0 {
+            report.Counterexamples = append(report.Counterexamples, n)
+        } else if least > report.MaxLeast {
+            report.MaxLeast, report.MaxLeastAt = least, n
+        }
+    }
+    return report
+}
+
+// goldbachFallback returns the least prime p > goldbachWindow with n-p
+// prime, or 0 if n is a counterexample
+func goldbachFallback(n int) int {
+    for p := goldbachWindow + 1; p <= n/2; p += 2 {
+        if IsPrime(p) && IsPrime(n-p) {
+            return p
+        }
+    }
+    return 0
+}
+
+// goldbachCounts verifies the even numbers in [lo, hi] and counts all their
+// decompositions, taking p from primes and testing n-p in table, which
+// marks the same primes
+func goldbachCounts(lo, hi int, primes []int, table []bool) GoldbachReport {
+    report := GoldbachReport{}
+    for n := lo; n <= hi; n += 2 {
+        part := GoldbachPartitions{N: n}
+        for _, p := range primes {
+            if p > n/2 {
+                break
+            }
+            if table[n-p] {
+                if part.Count == 0 {
+                    part.Least = p
+                }
+                part.Count++
+            }
+        }
+        report.Checked++
+        report.Partitions = append(report.Partitions, part)
+        if part.Count == 0 {
+            report.Counterexamples = append(report.Counterexamples, n)
+        } else if part.Least > report.MaxLeast {
+            report.MaxLeast, report.MaxLeastAt = part.Least, n
+        }
+    }
+    return report
+}
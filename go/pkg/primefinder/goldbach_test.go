@@ -0,0 +1,52 @@
+// goldbach_test.go
+package primefinder
+
+import (
+    "context"
+    "errors"
+    "reflect"
+    "testing"
+)
+
+func TestVerifyGoldbach(t *testing.T) {
+    ctx := context.Background()
+    
+    // 4 = 2+2, 6 = 3+3, 8 = 3+5, 10 = 3+7 = 5+5, 12 = 5+7
+    report, err := VerifyGoldbach(ctx, 1, 12, 2, true)
+    expected := GoldbachReport{
+        Checked:         5,
+        Counterexamples: []int{},
+        MaxLeast:        5,
+        MaxLeastAt:      12,
+        Partitions:      []GoldbachPartitions{{4, 1, 2}, {6, 1, 3}, {8, 1, 3}, {10, 2, 3}, {12, 1, 5}},
+    }
+    if err != nil || !reflect.DeepEqual(report, expected) {
+        t.Errorf("VerifyGoldbach(1, 12) = %+v, %v; expected %+v", report, err, expected)
+    }
+    
+    // Witnesses and counts agree across several chunks and workers; the
+    // largest least prime up to 10^6 is 523, for 503222
+    witnesses, err := VerifyGoldbach(ctx, 3, 1_000_000, 3, false)
+    if err != nil || witnesses.Checked != 499_999 || len(witnesses.Counterexamples) != 0 || witnesses.MaxLeast != 523 || witnesses.MaxLeastAt != 503222 {
+        t.Errorf("VerifyGoldbach(3, 10^6) = %+v, %v", witnesses, err)
+    }
+    counted, err := VerifyGoldbach(ctx, 99_000, 101_000, 2, true)
+    window, _ := VerifyGoldbach(ctx, 99_000, 101_000, 2, false)
+    if err != nil || counted.MaxLeast != window.MaxLeast || counted.Checked != 1001 || counted.Partitions[0].N != 99_000 {
+        t.Errorf("counts %+v disagree with witnesses %+v", counted.MaxLeast, window.MaxLeast)
+    }
+    
+    // Past the window the fallback search still finds decompositions
+    if p := goldbachFallback(2 * 1_000_003); p <= goldbachWindow || !IsPrime(p) || !IsPrime(2*1_000_003-p) {
+        t.Errorf("goldbachFallback found %d", p)
+    }
+    
+    cancelled, cancel := context.WithCancel(ctx)
+    cancel()
+    if _, err := VerifyGoldbach(cancelled, 1, 1_000_000, 2, false); !errors.Is(err, ErrCancelled) {
+        t.Errorf("cancelled verification: got %v, expected ErrCancelled", err)
+    }
+    if _, err := VerifyGoldbach(ctx, 1, MaxGoldbachCountEnd+1, 2, true); !errors.Is(err, ErrInvalidRange) {
+        t.Errorf("counts past MaxGoldbachCountEnd: got %v, expected ErrInvalidRange", err)
+    }
+}
@@ -0,0 +1,76 @@
+// known.go
+package primefinder
+
+// KnownPi holds authoritative values of pi(10^k), the number of primes not
+// exceeding 10^k, indexed by k
+var KnownPi = []int64{
+    0,
+    4,
+    25,
+    168,
+    1229,
+    9592,
+    78498,
+    664579,
+    5761455,
+    50847534,
+    455052511,
+    4118054813,
+    37607912018,
+    346065536839,
+    3204941750802,
+}
+
+// KnownValueCheck records a cross-check of a run's count against KnownPi
+type KnownValueCheck struct {
+    Expected int64 `json:"expected"`
+    Actual   int64 `json:"actual"`
+    Matched  bool  `json:"matched"`
+}
+
+// knownPiAt returns pi(n) when n is a power of ten covered by KnownPi
+func knownPiAt(n int) (int64, bool) {
+    power := 1
+    for k := range KnownPi {
+        if power == n {
+            return KnownPi[k], true
+        }
+        if power > n/10 {
+            break
+        }
+        power *= 10
+    }
+    return 0, false
+}
+
+// ExpectedPrimeCount returns the known prime count for [start, end] when the
+// range begins at the start of the number line or just past a power of ten
+// and ends on a power of ten
+func ExpectedPrimeCount(start, end int) (int64, bool) {
+    upper, ok := knownPiAt(end)
+    if !ok {
+        return 0, false
+    }
+    if start <= 2 {
+        return upper, true
+    }
+    lower, ok := knownPiAt(start - 1)
+    if !ok || start-1 > end {
+        return 0, false
+    }
+    return upper - lower, true
+}
+
+// CheckKnownCount cross-checks a computed count against KnownPi, returning nil
+// when the range does not align with the table
+func CheckKnownCount(start, end, count int) *KnownValueCheck {
+    expected, ok := ExpectedPrimeCount(start, end)
+    if !ok {
+        return nil
+    }
+    return &KnownValueCheck{
+        Expected: expected,
+        Actual:   int64(count),
+        Matched:  expected == int64(count),
+    }
+}
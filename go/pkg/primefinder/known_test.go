@@ -0,0 +1,39 @@
+// known_test.go
+package primefinder
+
+import "testing"
+
+func TestExpectedPrimeCount(t *testing.T) {
+    tests := []struct {
+        start, end int
+        expected   int64
+        ok         bool
+    }{
+        {1, 1000, 168, true},
+        {2, 1000000, 78498, true},
+        {1001, 10000, 1229 - 168, true},
+        {1, 999, 0, false},
+        {500, 1000, 0, false},
+        {1, 100000000000000, 3204941750802, true},
+    }
+    
+    for _, tt := range tests {
+        got, ok := ExpectedPrimeCount(tt.start, tt.end)
+        if ok != tt.ok || got != tt.expected {
+            t.Errorf("ExpectedPrimeCount(%d, %d) = %d, %v; expected %d, %v",
+                tt.start, tt.end, got, ok, tt.expected, tt.ok)
+        }
+    }
+}
+
+func TestCheckKnownCount(t *testing.T) {
+    if check := CheckKnownCount(1, 10000, 1229); check == nil || !check.Matched {
+        t.Errorf("Expected matching check, got %+v", check)
+    }
+    if check := CheckKnownCount(1, 10000, 1228); check == nil || check.Matched {
+        t.Errorf("Expected mismatching check, got %+v", check)
+    }
+    if check := CheckKnownCount(1, 12345, 0); check != nil {
+        t.Errorf("Expected no check for unaligned range, got %+v", check)
+    }
+}
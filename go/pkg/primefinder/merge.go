@@ -0,0 +1,112 @@
+// merge.go
+package primefinder
+
+import (
+    "container/heap"
+    "time"
+)
+
+// chunk is a contiguous sub-range handed to a worker; seq orders chunks
+// from the lowest range to the highest
+type chunk struct {
+    seq   int
+    start int
+    end   int
+}
+
+// chunkResult carries the ascending primes found in one chunk. A chunk that
+// kept exceeding its timeout is marked quarantined, one interrupted by
+// cancellation is marked cancelled, and one whose worker panicked carries
+// the failure in lost; none of these carries primes. A count-only search
+// reports how many primes the chunk held in count instead.
+type chunkResult struct {
+    chunk
+    primes      []int
+    count       int
+    quarantined bool
+    cancelled   bool
+    lost        error
+    elapsed     time.Duration
+    attempts    int
+}
+
+// status returns the outcome of the chunk as a ChunkReport status
+func (r chunkResult) status() string {
+    switch {
+    case r.lost != nil:
+        return ChunkLost
+    case r.quarantined:
+        return ChunkQuarantined
+    case r.cancelled:
+        return ChunkCancelled
+    }
+    return ChunkDone
+}
+
+// chunkHeap is a min-heap of chunk results ordered by sequence number
+type chunkHeap []chunkResult
+
+func (h chunkHeap) Len() int            { return len(h) }
+func (h chunkHeap) Less(i, j int) bool  { return h[i].seq < h[j].seq }
+func (h chunkHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *chunkHeap) Push(x interface{}) { *h = append(*h, x.(chunkResult)) }
+func (h *chunkHeap) Pop() interface{} {
+    old := *h
+    n := len(old)
+    item := old[n-1]
+    *h = old[:n-1]
+    return item
+}
+
+// mergeChunks performs a k-way merge of per-chunk sorted streams arriving
+// in any order. Because chunks cover disjoint ascending ranges, a chunk can
+// be emitted as soon as every lower-numbered chunk has been emitted, so only
+// the out-of-order chunks still in flight are ever buffered. done is called
+// after each chunk is emitted so the dispatcher can release another one.
+func mergeChunks(results <-chan chunkResult, emit func(chunkResult), done func()) {
+    pending := &chunkHeap{}
+    next := 0
+    
+    for result := range results {
+        heap.Push(pending, result)
+        for pending.Len() > 0 && (*pending)[0].seq == next {
+            emit(heap.Pop(pending).(chunkResult))
+            done()
+            next++
+        }
+    }
+}
+
+// takeChunks emits chunk results in the order they arrive, for callers that
+// do not need ascending output and would rather not wait on slow chunks
+func takeChunks(results <-chan chunkResult, emit func(chunkResult), done func()) {
+    for result := range results {
+        emit(result)
+        done()
+    }
+}
+
+// coverage tracks the longest prefix of a range whose chunks have all been
+// searched, for chunks merged in any order. Chunks past a gap wait in pending
+// until the gap is filled.
+type coverage struct {
+    end     int            // last number of the covered prefix
+    primes  int            // primes found in the covered prefix
+    pending map[int][2]int // start -> {end, primes} of chunks past a gap
+}
+
+// add records that [start, end] was fully searched and held primes primes
+func (c *coverage) add(start, end, primes int) {
+    if start != c.end+1 {
+        if c.pending == nil {
+            c.pending = make(map[int][2]int)
+        }
+        c.pending[start] = [2]int{end, primes}
+        return
+    }
+    c.end, c.primes = end, c.primes+primes
+    for next, ok := c.pending[c.end+1]; ok; next, ok = c.pending[c.end+1] {
+        delete(c.pending, c.end+1)
+        c.end, c.primes = next[0], c.primes+next[1]
+    }
+}
@@ -0,0 +1,86 @@
+// mersenne.go
+package primefinder
+
+import (
+    "context"
+    "fmt"
+    "math"
+    "math/big"
+)
+
+// lucasLehmerBlock is the number of Lucas-Lehmer steps between checks for
+// cancellation
+const lucasLehmerBlock = 256
+
+// MersenneNumber returns 2^p-1
+func MersenneNumber(p int) *big.Int {
+    M := new(big.Int).Lsh(big.NewInt(1), uint(p))
+    return M.Sub(M, big.NewInt(1))
+}
+
+// MersenneDigits returns the number of decimal digits of 2^p-1, the same as
+// of 2^p since no power of two is a power of ten
+func MersenneDigits(p int) int {
+    return int(float64(p)*math.Log10(2)) + 1
+}
+
+// IsMersennePrime reports whether 2^p-1 is prime, by the Lucas-Lehmer test
+func IsMersennePrime(p int) bool {
+    prime, _ := lucasLehmer(context.Background(), p)
+    return prime
+}
+
+// lucasLehmer decides whether M = 2^p-1 is prime. 2^p-1 can only be prime
+// for prime p; for odd prime p it is prime exactly when s = 0 after p-2
+// steps of s -> s^2 - 2 (mod M) from s = 4. Reducing mod M needs no
+// division: 2^p = 1 (mod M), so the high bits fold onto the low ones. ok is
+// false if ctx was done before the test finished.
+func lucasLehmer(ctx context.Context, p int) (prime, ok bool) {
+    if p < 2 || !IsPrime(p) {
+        return false, true
+    }
+    if p == 2 {
+        return true, true
+    }
+    
+    M := MersenneNumber(p)
+    s, high, two := big.NewInt(4), new(big.Int), big.NewInt(2)
+    for i := 0; i < p-2; i++ {
+        if i%lucasLehmerBlock == 0 && ctx.Err() != nil {
+            return false, false
+        }
+        s.Mul(s, s)
+        for s.BitLen() > p {
+            high.Rsh(s, uint(p))
+            s.And(s, M).Add(s, high)
+        }
+        if s.Cmp(M) == 0 {
+            s.SetInt64(0)
+        }
+        if s.Cmp(two) < 0 {
+            s.Add(s, M)
+        }
+        s.Sub(s, two)
+    }
+    return s.Sign() == 0, true
+}
+
+// FindMersenne tests 2^p-1 for each exponent p in [pMin, pMax] with
+// concurrent workers, composite p being ruled out at once. Results are
+// passed to onTested in ascending p. It returns the last p of the tested
+// prefix, which is pMax unless ctx was cancelled, in which case the error
+// wraps ErrCancelled.
+func FindMersenne(ctx context.Context, pMin, pMax, workers int, onTested func(FamilyResult)) (int, error) {
+    if pMin < 2 || pMin > pMax {
+        return pMin - 1, fmt.Errorf("%w: need 2 <= smallest exponent <= max exponent, got [%d, %d]", ErrInvalidRange, pMin, pMax)
+    }
+    if workers < 1 {
+        return pMin - 1, fmt.Errorf("%w: workers must be at least 1, got %d", ErrInvalidArgument, workers)
+    }
+    
+    through := scanFamily(ctx, pMin, pMax, workers, 0, lucasLehmer, onTested)
+    if through < pMax {
+        return through, fmt.Errorf("%w: tested exponents up to %d of %d", ErrCancelled, through, pMax)
+    }
+    return through, nil
+}
@@ -0,0 +1,56 @@
+// mersenne_test.go
+package primefinder
+
+import (
+    "context"
+    "errors"
+    "slices"
+    "testing"
+)
+
+func TestIsMersennePrime(t *testing.T) {
+    // Lucas-Lehmer must agree with the probable-prime test, including
+    // composite exponents and prime ones such as 11 where 2^p-1 = 23*89
+    for p := 0; p <= 300; p++ {
+        expected := p >= 2 && MersenneNumber(p).ProbablyPrime(bigRounds)
+        if got := IsMersennePrime(p); got != expected {
+            t.Errorf("IsMersennePrime(%d) = %v, expected %v", p, got, expected)
+        }
+    }
+}
+
+func TestMersenneDigits(t *testing.T) {
+    for _, p := range []int{1, 2, 3, 4, 10, 31, 127, 521, 4423} {
+        if got, expected := MersenneDigits(p), len(MersenneNumber(p).String()); got != expected {
+            t.Errorf("MersenneDigits(%d) = %d, expected %d", p, got, expected)
+        }
+    }
+}
+
+func TestFindMersenne(t *testing.T) {
+    // Exponents of the Mersenne primes (OEIS A000043)
+    expected := []int{2, 3, 5, 7, 13, 17, 19, 31, 61, 89, 107, 127, 521, 607, 1279}
+    var found []int
+    last := 1
+    through, err := FindMersenne(context.Background(), 2, 1300, 4, func(r FamilyResult) {
+        if r.X != last+1 {
+            t.Fatalf("p = %d reported after %d", r.X, last)
+        }
+        last = r.X
+        if r.Prime {
+            found = append(found, r.X)
+        }
+    })
+    if err != nil || through != 1300 || !slices.Equal(found, expected) {
+        t.Errorf("FindMersenne(2, 1300) = %d, %v with primes at %v, expected %v", through, err, found, expected)
+    }
+    
+    ctx, cancel := context.WithCancel(context.Background())
+    cancel()
+    if through, err := FindMersenne(ctx, 2, 1300, 4, func(FamilyResult) {}); !errors.Is(err, ErrCancelled) || through != 1 {
+        t.Errorf("cancelled FindMersenne = %d, %v, expected 1 and ErrCancelled", through, err)
+    }
+    if _, err := FindMersenne(context.Background(), 1, 10, 4, func(FamilyResult) {}); !errors.Is(err, ErrInvalidRange) {
+        t.Errorf("exponent 1: got %v, expected ErrInvalidRange", err)
+    }
+}
@@ -0,0 +1,114 @@
+// millerrabin.go
+package primefinder
+
+import (
+    "context"
+    "math/bits"
+    "math/rand/v2"
+    "time"
+)
+
+// mrWitnesses are Miller-Rabin bases that together decide primality exactly
+// for every 64-bit integer
+var mrWitnesses = []uint64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37}
+
+// IsPrimeMR tests n with Miller-Rabin over a deterministic witness set, so
+// the answer is exact for every uint64
+func IsPrimeMR(n uint64) bool {
+    return IsPrimeMRRounds(n, 0)
+}
+
+// IsPrimeMRRounds tests n with the given number of Miller-Rabin rounds using
+// random bases; a composite passes with probability at most 4^-rounds.
+// rounds <= 0 uses the deterministic witness set instead.
+func IsPrimeMRRounds(n uint64, rounds int) bool {
+    return isPrimeMRRand(n, rounds, nil)
+}
+
+// isPrimeMRRand is IsPrimeMRRounds drawing its bases from rng, or from the
+// global generator if rng is nil
+func isPrimeMRRand(n uint64, rounds int, rng *rand.Rand) bool {
+    if n < 2 {
+        return false
+    }
+    for _, p := range mrWitnesses {
+        if n%p == 0 {
+            return n == p
+        }
+    }
+    
+    // n-1 = d * 2^s with d odd
+    d := n - 1
+    s := bits.TrailingZeros64(d)
+    d >>= s
+    
+    if rounds <= 0 {
+        for _, a := range mrWitnesses {
+            if !mrRound(n, d, s, a) {
+                return false
+            }
+        }
+        return true
+    }
+    base := rand.Uint64N
+    if rng != nil {
+        base = rng.Uint64N
+    }
+    for i := 0; i < rounds; i++ {
+        if !mrRound(n, d, s, 2+base(n-3)) {
+            return false
+        }
+    }
+    return true
+}
+
+// mrRound reports whether n is a strong probable prime to base a, where
+// n-1 = d * 2^s
+func mrRound(n, d uint64, s int, a uint64) bool {
+    x := powMod(a, d, n)
+    if x == 1 || x == n-1 {
+        return true
+    }
+    for r := 1; r < s; r++ {
+        x = mulMod(x, x, n)
+        if x == n-1 {
+            return true
+        }
+    }
+    return false
+}
+
+// mulMod returns a*b mod m without overflow
+func mulMod(a, b, m uint64) uint64 {
+    hi, lo := bits.Mul64(a, b)
+    return bits.Rem64(hi, lo, m)
+}
+
+// powMod returns base^exp mod m
+func powMod(base, exp, m uint64) uint64 {
+    result := uint64(1)
+    base %= m
+    for exp > 0 {
+        if exp&1 == 1 {
+            result = mulMod(result, base, m)
+        }
+        base = mulMod(base, base, m)
+        exp >>= 1
+    }
+    return result
+}
+
+// FindRangeMR finds all primes in [start, end] in ascending order, testing
+// each candidate with IsPrimeMRRounds
+func FindRangeMR(start, end, rounds int) []int {
+    primes, _ := testRangeUntil(context.Background(), start, end, 1, mrTest(rounds, nil), time.Time{})
+    return primes
+}
+
+// mrTest adapts IsPrimeMRRounds to a test over ints, with bases from rng
+// unless it is nil
+func mrTest(rounds int, rng *rand.Rand) func(int) bool {
+    return func(n int) bool {
+        return n > 1 && isPrimeMRRand(uint64(n), rounds, rng)
+    }
+}
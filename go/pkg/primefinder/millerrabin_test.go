@@ -0,0 +1,55 @@
+// millerrabin_test.go
+package primefinder
+
+import (
+    "math"
+    "reflect"
+    "testing"
+)
+
+func TestIsPrimeMR(t *testing.T) {
+    tests := []struct {
+        n        uint64
+        expected bool
+    }{
+        {0, false},
+        {1, false},
+        {2, true},
+        {37, true},
+        {41, true},
+        {561, false},                 // Carmichael number
+        {3215031751, false},          // strong pseudoprime to bases 2, 3, 5, 7
+        {3825123056546413051, false}, // strong pseudoprime to bases 2 through 23
+        {2305843009213693951, true},  // Mersenne prime 2^61-1
+        {18446744073709551557, true}, // largest 64-bit prime
+        {math.MaxUint64, false},
+    }
+    for _, tt := range tests {
+        if got := IsPrimeMR(tt.n); got != tt.expected {
+            t.Errorf("IsPrimeMR(%d) = %v, expected %v", tt.n, got, tt.expected)
+        }
+        if got := IsPrimeMRRounds(tt.n, 20); got != tt.expected {
+            t.Errorf("IsPrimeMRRounds(%d, 20) = %v, expected %v", tt.n, got, tt.expected)
+        }
+    }
+}
+
+func TestIsPrimeMRAgreesWithTrialDivision(t *testing.T) {
+    for n := 0; n <= 100000; n++ {
+        if IsPrimeMR(uint64(n)) != IsPrime(n) {
+            t.Fatalf("IsPrimeMR(%d) = %v, IsPrime says %v", n, !IsPrime(n), IsPrime(n))
+        }
+    }
+}
+
+func TestConcurrentMillerRabin(t *testing.T) {
+    start, end := 1000000000, 1000050000
+    expected := FindRange(start, end)
+    result := FindRangeConcurrentConfig(start, end, 4, Config{Algorithm: AlgorithmMillerRabin})
+    if result.Err != nil || !reflect.DeepEqual(result.Primes, expected) {
+        t.Errorf("Miller-Rabin found %d primes (err %v), trial division %d", len(result.Primes), result.Err, len(expected))
+    }
+    if got := FindRangeMR(start, end, 10); !reflect.DeepEqual(got, expected) {
+        t.Errorf("FindRangeMR found %d primes, trial division %d", len(got), len(expected))
+    }
+}
@@ -0,0 +1,106 @@
+// nth.go
+package primefinder
+
+import (
+    "context"
+    "fmt"
+    "math"
+)
+
+// smallNthPrimes holds p_n for n < 6, below which the bounds of
+// NthPrimeBounds do not hold
+var smallNthPrimes = []int{0, 2, 3, 5, 7, 11}
+
+// NthPrimeBounds returns lo <= p_n <= hi for the n-th prime, n >= 1. For
+// n >= 6, n(ln n + ln ln n - 1) < p_n < n(ln n + ln ln n) (Dusart, Rosser).
+func NthPrimeBounds(n int) (lo, hi int) {
+    if n < len(smallNthPrimes) {
+        return smallNthPrimes[n], smallNthPrimes[n]
+    }
+    ln := math.Log(float64(n))
+    lnln := math.Log(ln)
+    return int(float64(n) * (ln + lnln - 1)), int(math.Ceil(float64(n) * (ln + lnln)))
+}
+
+// estimateNthPrime returns Cipolla's estimate of p_n,
+// n(ln n + ln ln n - 1 + (ln ln n - 2)/ln n), within the bounds
+func estimateNthPrime(n int) int {
+    lo, hi := NthPrimeBounds(n)
+    if lo == hi {
+        return lo
+    }
+    ln := math.Log(float64(n))
+    lnln := math.Log(ln)
+    return min(max(int(float64(n)*(ln+lnln-1+(lnln-2)/ln)), lo), hi)
+}
+
+// PrimePi returns π(x), the number of primes up to x, from a count-only
+// concurrent search with the algorithm and tuning of cfg
+func PrimePi(ctx context.Context, x, workers int, cfg Config) (int, error) {
+    if workers < 1 {
+        return 0, fmt.Errorf("%w: workers must be at least 1, got %d", ErrInvalidArgument, workers)
+    }
+    if x < 2 {
+        return 0, nil
+    }
+    cfg.CountOnly, cfg.Limit, cfg.Stride, cfg.Offset = true, 0, 0, 0
+    result := search(ctx, 2, x, workers, cfg, nil)
+    if err := incomplete(result); err != nil {
+        return 0, err
+    }
+    return result.Count, nil
+}
+
+// NthPrime returns the n-th prime, counting 2 as the first. It counts the
+// primes up to an estimate of p_n without storing them, then finds the
+// remaining ones with a limited search: upwards to the upper bound of
+// NthPrimeBounds if the estimate fell short, or downwards from the estimate
+// if it overshot. Only the primes between the estimate and p_n are held.
+func NthPrime(ctx context.Context, n, workers int, cfg Config) (int, error) {
+    if n < 1 {
+        return 0, fmt.Errorf("%w: n must be at least 1, got %d", ErrInvalidArgument, n)
+    }
+    if workers < 1 {
+        return 0, fmt.Errorf("%w: workers must be at least 1, got %d", ErrInvalidArgument, workers)
+    }
+    lo, hi := NthPrimeBounds(n)
+    if lo == hi {
+        return lo, nil
+    }
+    x := estimateNthPrime(n)
+    below, err := PrimePi(ctx, x, workers, cfg)
+    if err != nil {
+        return 0, err
+    }
+    
+    cfg.CountOnly, cfg.Unordered, cfg.Stride, cfg.Offset = false, false, 0, 0
+    var result SearchResult
+    if below >= n {
+        // p_n is the (below-n+1)-th largest prime up to x
+        cfg.Descending, cfg.Limit = true, below-n+1
+        result = search(ctx, lo, x, workers, cfg, nil)
+    } else {
+        cfg.Descending, cfg.Limit = false, n-below
+        result = search(ctx, x+1, hi, workers, cfg, nil)
+    }
+    if err := incomplete(result); err != nil && !result.LimitReached {
+        return 0, err
+    }
+    if len(result.Primes) != cfg.Limit {
+        return 0, fmt.Errorf("found %d of %d primes between the estimate %d and the bounds [%d, %d]", len(result.Primes), cfg.Limit, x, lo, hi)
+    }
+    return result.Primes[cfg.Limit-1], nil
+}
+
+// incomplete returns why a search did not cover its range, or nil
+func incomplete(result SearchResult) error {
+    switch {
+    case result.Err != nil:
+        return result.Err
+    case result.Cancelled:
+        return fmt.Errorf("%w: %d ranges left unsearched", ErrCancelled, len(result.Unsearched))
+    case len(result.Quarantined) > 0 || len(result.Unsearched) > 0:
+        return fmt.Errorf("%d chunks quarantined or unsearched; the count would be incomplete", len(result.Quarantined)+len(result.Unsearched))
+    }
+    return nil
+}
@@ -0,0 +1,62 @@
+// nth_test.go
+package primefinder
+
+import (
+    "context"
+    "errors"
+    "testing"
+)
+
+func TestNthPrimeBounds(t *testing.T) {
+    primes := FindRange(1, 200000)
+    for n := 1; n <= len(primes); n++ {
+        lo, hi := NthPrimeBounds(n)
+        if p := primes[n-1]; p < lo || p > hi {
+            t.Fatalf("NthPrimeBounds(%d) = [%d, %d], which misses %d", n, lo, hi, p)
+        }
+        if x := estimateNthPrime(n); x < lo || x > hi {
+            t.Fatalf("estimateNthPrime(%d) = %d, outside [%d, %d]", n, x, lo, hi)
+        }
+    }
+}
+
+func TestNthPrime(t *testing.T) {
+    primes := FindRange(1, 20000)
+    for n := 1; n <= len(primes); n += 97 {
+        if got, err := NthPrime(context.Background(), n, 4, Config{}); err != nil || got != primes[n-1] {
+            t.Fatalf("NthPrime(%d) = %d, %v; expected %d", n, got, err, primes[n-1])
+        }
+    }
+    tests := []struct {
+        n, expected int
+        cfg         Config
+    }{
+        {1000000, 15485863, Config{}},
+        {664579, 9999991, Config{Algorithm: AlgorithmSieve}},
+        {664580, 10000019, Config{Algorithm: AlgorithmSieve, Stride: 4, Offset: 1}},
+    }
+    for _, tt := range tests {
+        if got, err := NthPrime(context.Background(), tt.n, 4, tt.cfg); err != nil || got != tt.expected {
+            t.Errorf("NthPrime(%d) = %d, %v; expected %d", tt.n, got, err, tt.expected)
+        }
+    }
+    if _, err := NthPrime(context.Background(), 0, 4, Config{}); !errors.Is(err, ErrInvalidArgument) {
+        t.Errorf("NthPrime(0): got %v, expected ErrInvalidArgument", err)
+    }
+    ctx, cancel := context.WithCancel(context.Background())
+    cancel()
+    if _, err := NthPrime(ctx, 1000000, 4, Config{}); !errors.Is(err, ErrCancelled) {
+        t.Errorf("cancelled NthPrime: got %v, expected ErrCancelled", err)
+    }
+}
+
+func TestPrimePi(t *testing.T) {
+    tests := []struct{ x, expected int }{
+        {-5, 0}, {1, 0}, {2, 1}, {100, 25}, {1000000, 78498}, {10000000, 664579},
+    }
+    for _, tt := range tests {
+        if got, err := PrimePi(context.Background(), tt.x, 4, Config{}); err != nil || got != tt.expected {
+            t.Errorf("PrimePi(%d) = %d, %v; expected %d", tt.x, got, err, tt.expected)
+        }
+    }
+}
@@ -0,0 +1,71 @@
+// pairs.go
+package primefinder
+
+import "fmt"
+
+// PairGaps maps the prime pair kinds to the gap between their members
+var PairGaps = map[string]int{
+    "twin":   2,
+    "cousin": 4,
+    "sexy":   6,
+}
+
+// PairGap returns the gap of a pair kind
+func PairGap(kind string) (int, error) {
+    gap, ok := PairGaps[kind]
+    if !ok {
+        return 0, fmt.Errorf("%w: unknown prime pair kind %q (twin, cousin or sexy)", ErrInvalidArgument, kind)
+    }
+    return gap, nil
+}
+
+// PairFinder finds the pairs (p, p+gap) of primes, which need not be
+// consecutive: (7, 13) is a sexy pair though 11 lies between. Primes are
+// fed in ascending order, one at a time or in batches such as the chunks of
+// a search in range order; the primes within gap of the latest are held, so
+// a pair split across batches is still found. As a Pipeline stage it is the
+// pairs:G transform.
+type PairFinder struct {
+    Gap   int
+    Count int   // pairs found so far
+    held  []int // primes that may still be the lower member of a pair
+}
+
+// NewPairFinder returns a finder for pairs with the given gap
+func NewPairFinder(gap int) *PairFinder {
+    return &PairFinder{Gap: gap}
+}
+
+// Next feeds the next prime and reports whether it completes a pair, whose
+// lower member is p-Gap
+func (f *PairFinder) Next(p int) bool {
+    i := 0
+    for i < len(f.held) && f.held[i] < p-f.Gap {
+        i++
+    }
+    f.held = append(f.held[i:], p)
+    if f.held[0] != p-f.Gap {
+        return false
+    }
+    f.Count++
+    return true
+}
+
+// Add feeds a batch of primes and returns the lower members of the pairs
+// they complete
+func (f *PairFinder) Add(batch []int) []int {
+    var lower []int
+    for _, p := range batch {
+        if f.Next(p) {
+            lower = append(lower, p-f.Gap)
+        }
+    }
+    return lower
+}
+
+// Apply is Add, so a PairFinder can be a Pipeline stage
+func (f *PairFinder) Apply(batch []int) []int { return f.Add(batch) }
+
+// Flush has nothing to pass on: a held prime whose partner never came is
+// not in a pair
+func (f *PairFinder) Flush() []int { return nil }
@@ -0,0 +1,44 @@
+// pairs_test.go
+package primefinder
+
+import (
+    "errors"
+    "reflect"
+    "testing"
+)
+
+func TestPairFinder(t *testing.T) {
+    primes := FindRange(1, 100000)
+    isPrime := map[int]bool{}
+    for _, p := range primes {
+        isPrime[p] = true
+    }
+    for kind, gap := range PairGaps {
+        var expected []int
+        for _, p := range primes {
+            if isPrime[p+gap] && p+gap <= 100000 {
+                expected = append(expected, p)
+            }
+        }
+        
+        // Splitting the primes into batches anywhere finds the same pairs
+        for _, size := range []int{1, 2, 7, 1000, len(primes)} {
+            f := NewPairFinder(gap)
+            var got []int
+            for lo := 0; lo < len(primes); lo += size {
+                got = append(got, f.Add(primes[lo:min(lo+size, len(primes))])...)
+            }
+            if !reflect.DeepEqual(got, expected) || f.Count != len(expected) {
+                t.Errorf("%s pairs in batches of %d: found %d, expected %d", kind, size, f.Count, len(expected))
+            }
+        }
+    }
+    
+    // Twin prime pairs below 10^6
+    if f := NewPairFinder(2); len(f.Add(FindRange(1, 1000000))) != 8169 {
+        t.Errorf("found %d twin pairs below 10^6, expected 8169", f.Count)
+    }
+    if _, err := PairGap("triplet"); !errors.Is(err, ErrInvalidArgument) {
+        t.Errorf("PairGap(triplet): got %v, expected ErrInvalidArgument", err)
+    }
+}
@@ -0,0 +1,169 @@
+// pool.go
+package primefinder
+
+import (
+    "context"
+    "fmt"
+    "runtime"
+    "sync"
+    "time"
+)
+
+// Range is an inclusive range of numbers to search
+type Range struct {
+    Start int `json:"start"`
+    End   int `json:"end"`
+}
+
+// PoolResult is the outcome of one Range submitted to a Pool
+type PoolResult struct {
+    Range
+    Primes      []int         // ascending; empty with Config.CountOnly
+    Count       int           // primes found
+    Elapsed     time.Duration // time a worker spent on the range
+    Quarantined bool          // dropped after repeated timeouts; Primes is empty
+    Err         error         // ErrWorkerLost if the worker panicked
+}
+
+// Pool is a fixed set of workers that outlives any one search, so a
+// long-running library caller can answer many queries without starting
+// goroutines for each. Each submitted range is searched whole by one
+// worker, and results arrive on Results in completion order, so callers
+// wanting one query spread over all workers split it into several ranges.
+// Pool shares the chunk search of Search, but not its workers: Search and
+// FindRangeConcurrent still start their own for each call, since their
+// schedulers, autoscaling and ordered merge are shaped around one search.
+type Pool struct {
+    cfg     Config
+    jobs    chan poolJob
+    results chan PoolResult
+    workers sync.WaitGroup
+    
+    done      chan struct{} // closed by Close to release blocked submitters
+    closeOnce sync.Once
+    mu        sync.RWMutex // held for reading while submitting, for writing to close jobs
+    closed    bool
+}
+
+// poolJob is a submitted range with the configuration resolved for it
+type poolJob struct {
+    chunk
+    cfg Config
+}
+
+// New starts a pool of workers searching with the default configuration
+func New(workers int) (*Pool, error) {
+    return NewConfig(workers, Config{})
+}
+
+// NewConfig starts a pool of workers searching with the algorithm, candidate
+// set, chunk timeout and thread locking of cfg. The auto algorithm is
+// resolved for each range. Settings that shape a single search over many
+// chunks, such as the scheduler, limit and soft deadline, are ignored.
+func NewConfig(workers int, cfg Config) (*Pool, error) {
+    if workers < 1 {
+        return nil, fmt.Errorf("%w: workers must be at least 1, got %d", ErrInvalidArgument, workers)
+    }
+    if _, err := ResolveAlgorithm(cfg.Algorithm, 0); err != nil {
+        return nil, err
+    }
+    if err := ValidateStride(cfg.Stride, cfg.Offset); err != nil {
+        return nil, err
+    }
+    if err := ValidateWheel(cfg.Wheel); err != nil {
+        return nil, err
+    }
+    
+    p := &Pool{
+        cfg:     cfg,
+        jobs:    make(chan poolJob, workers),
+        results: make(chan PoolResult, workers),
+        done:    make(chan struct{}),
+    }
+    for i := 0; i < workers; i++ {
+        p.workers.Add(1)
+        go p.work(i)
+    }
+    go func() {
+        p.workers.Wait()
+        close(p.results)
+    }()
+    return p, nil
+}
+
+// Submit queues r for the next free worker. It blocks while the queue is
+// full, so results must be drained concurrently, until the pool is closed.
+// Submitting to a closed pool is an error.
+func (p *Pool) Submit(r Range) error {
+    if err := ValidateRange(r.Start, r.End, 1); err != nil {
+        return err
+    }
+    cfg := p.cfg
+    algorithm, err := ResolveAlgorithm(cfg.Algorithm, r.End)
+    if err != nil {
+        return err
+    }
+    cfg.Algorithm = algorithm
+    
+    p.mu.RLock()
+    defer p.mu.RUnlock()
+    if p.closed {
+        return fmt.Errorf("%w: pool is closed", ErrInvalidArgument)
+    }
+    select {
+    case p.jobs <- poolJob{chunk: chunk{start: r.Start, end: r.End}, cfg: cfg}:
+        return nil
+    case <-p.done:
+        return fmt.Errorf("%w: pool is closed", ErrInvalidArgument)
+    }
+}
+
+// Results returns the channel results are delivered on. It is closed once
+// the pool is closed and every submitted range has been searched.
+func (p *Pool) Results() <-chan PoolResult {
+    return p.results
+}
+
+// Close stops the pool accepting ranges, failing any Submit blocked on a
+// full queue. Workers finish the ranges already queued and then exit. Close
+// does not wait for them; drain Results for that. Closing twice is
+// harmless.
+func (p *Pool) Close() {
+    p.closeOnce.Do(func() { close(p.done) })
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    if !p.closed {
+        p.closed = true
+        close(p.jobs)
+    }
+}
+
+// work is the loop of worker id, which keeps its OS thread to itself with
+// Config.LockThreads
+func (p *Pool) work(id int) {
+    defer p.workers.Done()
+    if p.cfg.LockThreads {
+        runtime.LockOSThread()
+        defer runtime.UnlockOSThread()
+    }
+    
+    var stats WorkerStats
+    for job := range p.jobs {
+        if job.cfg.Algorithm == AlgorithmSieve {
+            job.cfg.basePrimes = sievingPrimes(isqrt(job.end))
+        }
+        r := runChunk(context.Background(), id, job.chunk, job.cfg, &stats)
+        count := len(r.primes)
+        if job.cfg.CountOnly {
+            count = r.count
+        }
+        p.results <- PoolResult{
+            Range:       Range{Start: job.start, End: job.end},
+            Primes:      r.primes,
+            Count:       count,
+            Elapsed:     r.elapsed,
+            Quarantined: r.quarantined,
+            Err:         r.lost,
+        }
+    }
+}
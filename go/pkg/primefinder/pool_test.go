@@ -0,0 +1,118 @@
+// pool_test.go
+package primefinder
+
+import (
+    "errors"
+    "slices"
+    "testing"
+    "time"
+)
+
+func TestPool(t *testing.T) {
+    pool, err := New(3)
+    if err != nil {
+        t.Fatal(err)
+    }
+    ranges := []Range{{1, 1000}, {1001, 50_000}, {10_000_000, 10_010_000}, {24, 28}, {2, 2}}
+    go func() {
+        // One pool serves every query; submit from a second goroutine as a
+        // server handler would while results are drained
+        for _, r := range ranges {
+            if err := pool.Submit(r); err != nil {
+                t.Error(err)
+            }
+        }
+        pool.Close()
+    }()
+    
+    got := map[Range][]int{}
+    for r := range pool.Results() {
+        if r.Err != nil || r.Count != len(r.Primes) {
+            t.Errorf("%v: count %d with %d primes, err %v", r.Range, r.Count, len(r.Primes), r.Err)
+        }
+        got[r.Range] = r.Primes
+    }
+    for _, r := range ranges {
+        if expected := FindRange(r.Start, r.End); !slices.Equal(got[r], expected) {
+            t.Errorf("%v: got %d primes, expected %d", r, len(got[r]), len(expected))
+        }
+    }
+    
+    if err := pool.Submit(Range{1, 10}); !errors.Is(err, ErrInvalidArgument) {
+        t.Errorf("Submit after Close: got %v, expected ErrInvalidArgument", err)
+    }
+    pool.Close()
+}
+
+func TestPoolConfig(t *testing.T) {
+    pool, err := NewConfig(2, Config{Algorithm: AlgorithmSieve, CountOnly: true, Stride: 4, Offset: 3})
+    if err != nil {
+        t.Fatal(err)
+    }
+    if err := pool.Submit(Range{100, 1}); !errors.Is(err, ErrInvalidRange) {
+        t.Errorf("reversed range: got %v, expected ErrInvalidRange", err)
+    }
+    if err := pool.Submit(Range{1, 1_000_000}); err != nil {
+        t.Fatal(err)
+    }
+    pool.Close()
+    r := <-pool.Results()
+    if expected := 39322; r.Count != expected || len(r.Primes) != 0 {
+        t.Errorf("primes 4n+3 up to 10^6: got count %d with %d listed, expected %d and none listed", r.Count, len(r.Primes), expected)
+    }
+    if _, open := <-pool.Results(); open {
+        t.Error("Results still open after the last result")
+    }
+    
+    for _, tc := range []struct {
+        workers int
+        cfg     Config
+        err     error
+    }{
+        {0, Config{}, ErrInvalidArgument},
+        {1, Config{Algorithm: "bogus"}, ErrInvalidArgument},
+        {1, Config{Wheel: 7}, ErrInvalidArgument},
+    } {
+        if _, err := NewConfig(tc.workers, tc.cfg); !errors.Is(err, tc.err) {
+            t.Errorf("NewConfig(%d, %+v): got %v, expected %v", tc.workers, tc.cfg, err, tc.err)
+        }
+    }
+}
+
+func TestPoolCloseWithBlockedSubmit(t *testing.T) {
+    pool, err := New(1)
+    if err != nil {
+        t.Fatal(err)
+    }
+    // Nobody drains Results, so the worker, the queue and then Submit block
+    submitted := make(chan int)
+    go func() {
+        n := 0
+        for i := 0; i < 10; i++ {
+            if pool.Submit(Range{1, 100}) == nil {
+                n++
+            }
+        }
+        submitted <- n
+    }()
+    time.Sleep(50 * time.Millisecond)
+    
+    closed := make(chan struct{})
+    go func() {
+        pool.Close()
+        close(closed)
+    }()
+    select {
+    case <-closed:
+    case <-time.After(5 * time.Second):
+        t.Fatal("Close deadlocked behind a blocked Submit")
+    }
+    n := <-submitted
+    results := 0
+    for range pool.Results() {
+        results++
+    }
+    if n == 10 || results != n {
+        t.Errorf("%d ranges accepted and %d results, expected the same number and fewer than 10", n, results)
+    }
+}
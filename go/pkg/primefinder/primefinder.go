@@ -0,0 +1,104 @@
+// primefinder.go
+
+// Package primefinder finds primes in integer ranges, sequentially or with a
+// pool of concurrent workers, and provides the checks and transforms used by
+// the prime-finder command.
+package primefinder
+
+import (
+    "context"
+    "math"
+    "math/big"
+    "time"
+)
+
+// IsPrime checks if a number is prime: below 2^20 by table lookup, and
+// above using trial division by the cached base primes (see WarmCache),
+// continuing with 6k-1 and 6k+1 past them
+func IsPrime(n int) bool {
+    return wheel6.isPrime(n)
+}
+
+// primeCountBound returns an upper bound on the number of primes in
+// [start, end], used to size result buffers up front. It uses the
+// Montgomery-Vaughan bound pi(x+y) - pi(x) <= 2y/ln(y) for longer ranges.
+func primeCountBound(start, end int) int {
+    width := end - start + 1
+    if width <= 0 {
+        return 0
+    }
+    if width < 64 {
+        return width/2 + 1
+    }
+    return int(2*float64(width)/math.Log(float64(width))) + 1
+}
+
+// FindRange finds all primes in [start, end] in ascending order. The result
+// buffer is sized up front so it is never regrown while searching.
+func FindRange(start, end int) []int {
+    primes := make([]int, 0, primeCountBound(start, end))
+    for i := start; i <= end; i++ {
+        if IsPrime(i) {
+            primes = append(primes, i)
+        }
+    }
+    return primes
+}
+
+// findRangeUntil is FindRange with a deadline that is checked
+// periodically; ok is false if the deadline passed before the range was done
+func findRangeUntil(start, end int, deadline time.Time) (primes []int, ok bool) {
+    return testRangeUntil(context.Background(), start, end, 1, IsPrime, deadline)
+}
+
+// testRangeUntil collects the candidates start, start+stride, ... up to end
+// passing isPrime, checking periodically whether ctx is cancelled or the
+// deadline has passed
+func testRangeUntil(ctx context.Context, start, end, stride int, isPrime func(int) bool, deadline time.Time) (primes []int, ok bool) {
+    stride = max(stride, 1)
+    candidates := 0
+    if start <= end {
+        candidates = (end-start)/stride + 1
+    }
+    primes = make([]int, 0, min(primeCountBound(start, end), candidates))
+    for i, n := 0, start; i < candidates; i, n = i+1, n+stride {
+        if i%1024 == 0 && expired(ctx, deadline) {
+            return nil, false
+        }
+        if isPrime(n) {
+            primes = append(primes, n)
+        }
+    }
+    return primes, true
+}
+
+// countRangeUntil is testRangeUntil that only counts the candidates passing
+// isPrime
+func countRangeUntil(ctx context.Context, start, end, stride int, isPrime func(int) bool, deadline time.Time) (count int, ok bool) {
+    stride = max(stride, 1)
+    candidates := 0
+    if start <= end {
+        candidates = (end-start)/stride + 1
+    }
+    for i, n := 0, start; i < candidates; i, n = i+1, n+stride {
+        if i%1024 == 0 && expired(ctx, deadline) {
+            return 0, false
+        }
+        if isPrime(n) {
+            count++
+        }
+    }
+    return count, true
+}
+
+// expired reports whether a search should stop because ctx is cancelled or
+// the deadline has passed; a zero deadline never expires
+func expired(ctx context.Context, deadline time.Time) bool {
+    return ctx.Err() != nil || (!deadline.IsZero() && time.Now().After(deadline))
+}
+
+// IsProbablePrime tests n with Miller-Rabin plus Baillie-PSW, which has no
+// known counterexamples and is exact below 2^64
+func IsProbablePrime(n int) bool {
+    return n > 1 && big.NewInt(int64(n)).ProbablyPrime(bigRounds)
+}
@@ -0,0 +1,91 @@
+// proth.go
+package primefinder
+
+import (
+    "context"
+    "fmt"
+    "math/big"
+)
+
+// prothBlock is the number of squarings done per big.Int.Exp call in a Proth
+// test; cancellation is checked between blocks
+const prothBlock = 256
+
+// prothBases are the candidate quadratic non-residues tried for Proth's test
+var prothBases = basePrimes(200)[1:]
+
+// ProthNumber returns k*2^n+1
+func ProthNumber(k, n int) *big.Int {
+    N := new(big.Int).Lsh(big.NewInt(int64(k)), uint(n))
+    return N.Add(N, big.NewInt(1))
+}
+
+// IsProthPrime reports whether k*2^n+1 is prime, for odd k >= 1 and n >= 1
+func IsProthPrime(k, n int) bool {
+    prime, _ := prothTest(context.Background(), k, n)
+    return prime
+}
+
+// prothTest decides whether N = k*2^n+1 is prime by Proth's theorem: when
+// k < 2^n, N is prime exactly when a^((N-1)/2) = -1 (mod N) for a quadratic
+// non-residue a. Smaller n, and the rare N with no small non-residue (such as
+// squares), fall back to a probable-prime test. ok is false if ctx was done
+// before the test finished.
+func prothTest(ctx context.Context, k, n int) (prime, ok bool) {
+    N := ProthNumber(k, n)
+    if n < 63 && k >= 1<<n {
+        return N.ProbablyPrime(bigRounds), true
+    }
+    
+    var a *big.Int
+    for _, p := range prothBases {
+        base := big.NewInt(int64(p))
+        switch big.Jacobi(base, N) {
+        case -1:
+            a = base
+        case 0:
+            return N.Cmp(base) == 0, true
+        }
+        if a != nil {
+            break
+        }
+    }
+    if a == nil {
+        return N.ProbablyPrime(bigRounds), true
+    }
+    
+    // (N-1)/2 = k*2^(n-1): raise a to k, then square n-1 times
+    x := new(big.Int).Exp(a, big.NewInt(int64(k)), N)
+    for left := n - 1; left > 0; left -= prothBlock {
+        if ctx.Err() != nil {
+            return false, false
+        }
+        x.Exp(x, new(big.Int).Lsh(big.NewInt(1), uint(min(left, prothBlock))), N)
+    }
+    minusOne := new(big.Int).Sub(N, big.NewInt(1))
+    return x.Cmp(minusOne) == 0, true
+}
+
+// FindProth tests k*2^n+1 for each n in [nMin, nMax] with concurrent
+// workers. Results are passed to onTested in ascending n. It returns the
+// last n of the tested prefix, which is nMax unless ctx was cancelled, in
+// which case the error wraps ErrCancelled.
+func FindProth(ctx context.Context, k, nMin, nMax, workers int, onTested func(FamilyResult)) (int, error) {
+    if k < 1 || k%2 == 0 {
+        return nMin - 1, fmt.Errorf("%w: k must be odd and positive, got %d", ErrInvalidArgument, k)
+    }
+    if nMin < 1 || nMin > nMax {
+        return nMin - 1, fmt.Errorf("%w: need 1 <= n-min <= n-max, got [%d, %d]", ErrInvalidRange, nMin, nMax)
+    }
+    if workers < 1 {
+        return nMin - 1, fmt.Errorf("%w: workers must be at least 1, got %d", ErrInvalidArgument, workers)
+    }
+    
+    through := scanFamily(ctx, nMin, nMax, workers, 0, func(ctx context.Context, n int) (bool, bool) {
+        return prothTest(ctx, k, n)
+    }, onTested)
+    if through < nMax {
+        return through, fmt.Errorf("%w: tested n up to %d of %d", ErrCancelled, through, nMax)
+    }
+    return through, nil
+}
@@ -0,0 +1,49 @@
+// proth_test.go
+package primefinder
+
+import (
+    "context"
+    "errors"
+    "slices"
+    "testing"
+)
+
+func TestIsProthPrime(t *testing.T) {
+    // Proth's test must agree with the probable-prime test, including the
+    // small n where k >= 2^n and squares such as 3*2^3+1 = 25
+    for _, k := range []int{1, 3, 5, 7, 9, 13, 15, 27, 1023} {
+        for n := 1; n <= 200; n++ {
+            if got, expected := IsProthPrime(k, n), ProthNumber(k, n).ProbablyPrime(bigRounds); got != expected {
+                t.Errorf("IsProthPrime(%d, %d) = %v, expected %v", k, n, got, expected)
+            }
+        }
+    }
+}
+
+func TestFindProth(t *testing.T) {
+    // n with 3*2^n+1 prime (OEIS A002253)
+    expected := []int{1, 2, 5, 6, 8, 12, 18, 30, 36, 41, 66, 189, 201, 209, 276, 353, 408, 438, 534}
+    var found []int
+    last := 0
+    through, err := FindProth(context.Background(), 3, 1, 600, 4, func(r FamilyResult) {
+        if r.X != last+1 {
+            t.Fatalf("n = %d reported after %d", r.X, last)
+        }
+        last = r.X
+        if r.Prime {
+            found = append(found, r.X)
+        }
+    })
+    if err != nil || through != 600 || !slices.Equal(found, expected) {
+        t.Errorf("FindProth(3, 1, 600) = %d, %v with primes at %v, expected %v", through, err, found, expected)
+    }
+    
+    ctx, cancel := context.WithCancel(context.Background())
+    cancel()
+    if through, err := FindProth(ctx, 3, 1, 600, 4, func(FamilyResult) {}); !errors.Is(err, ErrCancelled) || through != 0 {
+        t.Errorf("cancelled FindProth = %d, %v, expected 0 and ErrCancelled", through, err)
+    }
+    if _, err := FindProth(context.Background(), 4, 1, 10, 4, func(FamilyResult) {}); !errors.Is(err, ErrInvalidArgument) {
+        t.Errorf("even k: got %v, expected ErrInvalidArgument", err)
+    }
+}
@@ -0,0 +1,171 @@
+// schedule.go
+package primefinder
+
+import (
+    "fmt"
+    "math"
+    "time"
+)
+
+// Chunk schedulers selectable with Config.Scheduler
+const (
+    SchedulerDynamic = "dynamic" // shrinking chunks handed out on demand (default)
+    SchedulerStatic  = "static"  // one equal chunk per worker
+)
+
+// minDynamicChunk is the smallest chunk the dynamic scheduler hands out, so
+// per-chunk overhead stays small next to the work in it
+const minDynamicChunk = 1 << 14
+
+// dynamicChunksPerWorker is the number of chunks per worker the dynamic
+// scheduler still plans for ranges too narrow for minDynamicChunk
+const dynamicChunksPerWorker = 4
+
+// minDeadlineChunk is the smallest piece a chunk is split into ahead of a
+// soft deadline
+const minDeadlineChunk = 1 << 10
+
+// WorkerStats describes how busy one worker was during a search
+type WorkerStats struct {
+    Chunks      int
+    Busy        time.Duration // time spent searching chunks
+    Utilization float64       // Busy as a fraction of the search duration
+}
+
+// costModel returns the estimated cost of searching [0, x), in arbitrary
+// units, for an algorithm whose cost per number varies across a range. It
+// must be increasing in x.
+type costModel func(x float64) float64
+
+// costModels holds the cost models of algorithms whose cost per number grows
+// with n. Trial division costs about one step for every number, which most
+// composites do not get past, plus sqrt(n)/3 steps of the 6k±1 loop for each
+// of the 1/ln(n) numbers that are prime. Its cumulative cost is therefore
+// about x + (2/9) x^1.5 / ln(x). Sieving and Miller-Rabin cost about the
+// same for every number of a range, so they are planned by width.
+var costModels = map[string]costModel{
+    AlgorithmTrial: func(x float64) float64 {
+        x = max(x, 0)
+        return x + 2*math.Pow(x, 1.5)/(9*math.Log(max(x, 3)))
+    },
+}
+
+// reflectCost returns the cost model of the range [start, end] traversed
+// from end down, where x stands for the number start+end-x
+func reflectCost(cost costModel, start, end int) costModel {
+    return func(x float64) float64 {
+        return -cost(float64(start+end+1) - x)
+    }
+}
+
+// planChunks splits [start, end] into chunks for the given scheduler.
+//
+// The static plan gives every worker one equal share up front, so one worker
+// holding the most expensive numbers finishes last while the others sit idle.
+// The dynamic plan uses guided self-scheduling: each chunk is a 1/(2*workers)
+// share of what is still unassigned, so chunks start large to keep overhead
+// low and shrink towards the end of the range, where candidates are most
+// expensive, letting idle workers pick up the remaining work in small pieces.
+// A positive maxChunk caps the size of every chunk.
+//
+// With a cost model, shares are measured in estimated cost instead of
+// width, so chunks of expensive numbers are narrower and each takes about
+// the same time.
+func planChunks(start, end, workers int, scheduler string, maxChunk int, cost costModel) ([]chunk, error) {
+    width := end - start + 1
+    if width <= 0 {
+        return nil, nil
+    }
+    
+    var next func(lo int) int
+    switch scheduler {
+    case "", SchedulerDynamic:
+        floor := dynamicChunkFloor(width, workers)
+        next = func(lo int) int {
+            remaining := end - lo + 1
+            size := (remaining + 2*workers - 1) / (2 * workers)
+            if cost != nil {
+                // A share of the remaining cost rather than of the width
+                size = costWidth(cost, lo, end, (cost(float64(end+1))-cost(float64(lo)))/float64(2*workers))
+            }
+            return max(size, floor)
+        }
+    case SchedulerStatic:
+        size := max(width/workers, 1)
+        next = func(lo int) int {
+            if cost != nil {
+                // An equal share of the cost of the whole range
+                return max(costWidth(cost, lo, end, (cost(float64(end+1))-cost(float64(start)))/float64(workers)), 1)
+            }
+            return size
+        }
+    default:
+        return nil, fmt.Errorf("%w: unknown scheduler %q", ErrInvalidArgument, scheduler)
+    }
+    
+    var chunks []chunk
+    for lo := start; lo <= end; {
+        size := next(lo)
+        if maxChunk > 0 {
+            size = min(size, maxChunk)
+        }
+        hi := min(lo+size-1, end)
+        chunks = append(chunks, chunk{seq: len(chunks), start: lo, end: hi})
+        if hi == end {
+            break
+        }
+        lo = hi + 1
+    }
+    return chunks, nil
+}
+
+// dynamicChunkFloor returns the smallest chunk the dynamic scheduler hands
+// out for a range of width numbers: minDynamicChunk, or less if the range
+// is too narrow to give each worker dynamicChunksPerWorker such chunks
+func dynamicChunkFloor(width, workers int) int {
+    return max(min(minDynamicChunk, width/(workers*dynamicChunksPerWorker)), 1)
+}
+
+// costWidth returns the width of the narrowest chunk [lo, hi] within
+// [lo, end] whose estimated cost reaches target, or end-lo+1 if none does
+func costWidth(cost costModel, lo, end int, target float64) int {
+    base := cost(float64(lo))
+    l, h := lo, end
+    for l < h {
+        mid := l + (h-l)/2
+        if cost(float64(mid+1))-base >= target {
+            h = mid
+        } else {
+            l = mid + 1
+        }
+    }
+    return l - lo + 1
+}
+
+// deadlineChunk returns the size of chunk a worker searching rate numbers a
+// second should be handed with left until a soft deadline. Half the time is
+// held back for the chunks already queued ahead of it. Sizes at most double
+// from the previous chunk, since the rate is measured on cheaper numbers
+// than those still to come. While the rate is unknown it returns a small
+// probe chunk to measure it with.
+func deadlineChunk(rate float64, left time.Duration, prev int) int {
+    if rate <= 0 || math.IsNaN(rate) {
+        return minDynamicChunk
+    }
+    size := float64(2 * prev)
+    if !math.IsInf(rate, 0) {
+        size = min(size, rate*left.Seconds()/2)
+    }
+    return max(int(size), minDeadlineChunk)
+}
+
+// limitChunk caps chunk sizes for a search stopping after limit primes, so
+// one chunk per worker is expected to hold them all: near end about one
+// number in ln(end) is prime. The cap never exceeds a positive maxChunk.
+func limitChunk(limit, end, workers, maxChunk int) int {
+    size := max(int(float64(limit)*math.Log(float64(max(end, 3)))/float64(workers)), minDynamicChunk)
+    if maxChunk > 0 {
+        size = min(size, maxChunk)
+    }
+    return size
+}
@@ -0,0 +1,161 @@
+// schedule_test.go
+package primefinder
+
+import (
+    "errors"
+    "math"
+    "testing"
+    "time"
+)
+
+func TestPlanChunks(t *testing.T) {
+    tests := []struct {
+        name       string
+        start, end int
+        workers    int
+        scheduler  string
+        maxChunk   int
+        chunks     int
+    }{
+        {"static even", 1, 100, 4, SchedulerStatic, 0, 4},
+        {"static remainder", 1, 10, 3, SchedulerStatic, 0, 4},
+        {"static tiny", 1, 2, 4, SchedulerStatic, 0, 2},
+        {"static capped", 1, 100, 4, SchedulerStatic, 10, 10},
+        {"dynamic small range", 1, 1000, 4, SchedulerDynamic, 0, 14},
+        {"dynamic below minimum chunk", 1, 10000, 4, SchedulerDynamic, 0, 14},
+        {"dynamic tiny", 1, 10, 4, SchedulerDynamic, 0, 9},
+        {"dynamic default", 1, 10000000, 4, "", 0, 41},
+        {"dynamic capped", 1, 10000000, 4, "", 100000, 114},
+        {"empty", 10, 5, 4, SchedulerDynamic, 0, 0},
+    }
+    for _, tt := range tests {
+        plan, err := planChunks(tt.start, tt.end, tt.workers, tt.scheduler, tt.maxChunk, nil)
+        if err != nil {
+            t.Fatalf("%s: %v", tt.name, err)
+        }
+        if len(plan) != tt.chunks {
+            t.Errorf("%s: %d chunks, expected %d", tt.name, len(plan), tt.chunks)
+        }
+        
+        // Chunks must tile the range in order
+        next := tt.start
+        for i, c := range plan {
+            if c.seq != i || c.start != next || c.end < c.start {
+                t.Fatalf("%s: chunk %d = %+v does not continue from %d", tt.name, i, c, next)
+            }
+            next = c.end + 1
+        }
+        if len(plan) > 0 && next != tt.end+1 {
+            t.Errorf("%s: chunks end at %d, expected %d", tt.name, next-1, tt.end)
+        }
+    }
+    
+    if _, err := planChunks(1, 100, 4, "round-robin", 0, nil); !errors.Is(err, ErrInvalidArgument) {
+        t.Errorf("Unknown scheduler gave %v", err)
+    }
+}
+
+func TestDynamicChunksShrink(t *testing.T) {
+    plan, _ := planChunks(1, 100000000, 8, SchedulerDynamic, 0, nil)
+    for i := 1; i < len(plan); i++ {
+        prev, cur := plan[i-1].end-plan[i-1].start, plan[i].end-plan[i].start
+        if cur > prev {
+            t.Fatalf("Chunk %d (%d wide) is larger than chunk %d (%d wide)", i, cur+1, i-1, prev+1)
+        }
+    }
+}
+
+func TestCostWeightedPlan(t *testing.T) {
+    cost := costModels[AlgorithmTrial]
+    chunkCost := func(m costModel, c chunk) float64 { return m(float64(c.end+1)) - m(float64(c.start)) }
+    
+    // Static shares of trial division cost the same and narrow towards the
+    // expensive end of the range
+    const start, end = 1, 100000000
+    plan, _ := planChunks(start, end, 4, SchedulerStatic, 0, cost)
+    if len(plan) != 4 || plan[0].start != start || plan[3].end != end {
+        t.Fatalf("Static cost plan = %+v, expected 4 chunks over the range", plan)
+    }
+    share := (cost(end+1) - cost(start)) / 4
+    for i, c := range plan {
+        if got := chunkCost(cost, c); math.Abs(got-share)/share > 1e-3 {
+            t.Errorf("Chunk %d %+v costs %.4g, expected %.4g", i, c, got, share)
+        }
+        if i > 0 && c.end-c.start >= plan[i-1].end-plan[i-1].start {
+            t.Errorf("Chunk %d %+v is not narrower than chunk %d %+v", i, c, i-1, plan[i-1])
+        }
+    }
+    
+    // Dynamic chunks shrink in cost until they reach the minimum width
+    plan, _ = planChunks(start, end, 4, SchedulerDynamic, 0, cost)
+    for i := 1; i < len(plan) && plan[i].end-plan[i].start+1 > minDynamicChunk; i++ {
+        if chunkCost(cost, plan[i]) > chunkCost(cost, plan[i-1])*(1+1e-9) {
+            t.Fatalf("Chunk %d costs more than chunk %d", i, i-1)
+        }
+    }
+    
+    // Reflected for a descending search, the first chunks are the narrow,
+    // expensive ones at the top of the range
+    plan, _ = planChunks(start, end, 4, SchedulerStatic, 0, reflectCost(cost, start, end))
+    if len(plan) != 4 || plan[0].end-plan[0].start >= plan[3].end-plan[3].start {
+        t.Errorf("Reflected plan = %+v, expected 4 chunks widening", plan)
+    }
+    mirrored := chunk{start: start + end - plan[0].end, end: start + end - plan[0].start}
+    if got := chunkCost(cost, mirrored); math.Abs(got-share)/share > 1e-3 {
+        t.Errorf("Top chunk %+v costs %.4g, expected %.4g", mirrored, got, share)
+    }
+}
+
+func TestWorkerStats(t *testing.T) {
+    result := FindRangeConcurrentConfig(1, 1000000, 4, Config{})
+    if len(result.Workers) != 4 {
+        t.Fatalf("Expected stats for 4 workers, got %d", len(result.Workers))
+    }
+    chunks := 0
+    for _, w := range result.Workers {
+        chunks += w.Chunks
+        if w.Utilization < 0 || w.Utilization > 1.01 {
+            t.Errorf("Utilization %v out of range", w.Utilization)
+        }
+    }
+    if chunks != len(result.ChunkCosts) {
+        t.Errorf("Workers processed %d chunks, expected %d", chunks, len(result.ChunkCosts))
+    }
+}
+
+func TestDeadlineChunk(t *testing.T) {
+    tests := []struct {
+        rate     float64
+        left     time.Duration
+        prev     int
+        expected int
+    }{
+        {0, time.Second, 5000, minDynamicChunk},
+        {math.Inf(1), time.Second, 5000, 10000},
+        {1e6, 2 * time.Second, 1 << 30, 1000000},
+        {1e6, 2 * time.Second, 300000, 600000},
+        {1e6, time.Microsecond, 1 << 30, minDeadlineChunk},
+        {1e6, -time.Second, 1 << 30, minDeadlineChunk},
+    }
+    for _, tt := range tests {
+        if got := deadlineChunk(tt.rate, tt.left, tt.prev); got != tt.expected {
+            t.Errorf("deadlineChunk(%g, %v, %d) = %d, expected %d", tt.rate, tt.left, tt.prev, got, tt.expected)
+        }
+    }
+}
+
+func TestLimitChunk(t *testing.T) {
+    tests := []struct {
+        limit, end, workers, maxChunk int
+        expected                      int
+    }{
+        {1000000, 1000000000000, 4, 0, 6907755},
+        {10, 1000, 4, 0, minDynamicChunk},
+        {1000000, 1000000000000, 4, 1 << 20, 1 << 20},
+    }
+    for _, tt := range tests {
+        if got := limitChunk(tt.limit, tt.end, tt.workers, tt.maxChunk); got != tt.expected {
+            t.Errorf("limitChunk(%d, %d, %d, %d) = %d, expected %d", tt.limit, tt.end, tt.workers, tt.maxChunk, got, tt.expected)
+        }
+    }
+}
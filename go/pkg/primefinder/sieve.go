@@ -0,0 +1,214 @@
+// sieve.go
+package primefinder
+
+import (
+    "context"
+    "fmt"
+    "math/big"
+    "time"
+)
+
+// Search algorithms selectable with Config.Algorithm
+const (
+    AlgorithmAuto        = "auto"         // sieve for large ranges, trial division otherwise
+    AlgorithmTrial       = "trial"        // trial division of every candidate
+    AlgorithmSieve       = "sieve"        // segmented Sieve of Eratosthenes
+    AlgorithmMillerRabin = "miller-rabin" // Miller-Rabin test of every candidate
+)
+
+const (
+    // sieveThreshold is the range end from which AlgorithmAuto sieves
+    sieveThreshold = 10_000_000
+    // maxSieveEnd bounds the base primes, up to sqrt(end), that a sieve
+    // builds before its first segment: at the cap that is the 5.76 million
+    // primes up to 10^8, about 46 MB (see basePrimes)
+    maxSieveEnd = 10_000_000_000_000_000
+    // segmentSize is the number of candidates sieved at a time
+    segmentSize = 1 << 16
+)
+
+// ResolveAlgorithm returns the algorithm a search ending at end runs with for
+// the given Config.Algorithm name; the empty name means AlgorithmAuto
+func ResolveAlgorithm(name string, end int) (string, error) {
+    switch name {
+    case "", AlgorithmAuto:
+        if end >= sieveThreshold && end <= maxSieveEnd {
+            return AlgorithmSieve, nil
+        }
+        return AlgorithmTrial, nil
+    case AlgorithmTrial, AlgorithmMillerRabin:
+        return name, nil
+    case AlgorithmSieve:
+        if end > maxSieveEnd {
+            return "", fmt.Errorf("%w: the sieve supports ranges up to %d", ErrInvalidArgument, maxSieveEnd)
+        }
+        return AlgorithmSieve, nil
+    }
+    return "", fmt.Errorf("%w: unknown algorithm %q", ErrInvalidArgument, name)
+}
+
+// isqrt returns the floor of the square root of n
+func isqrt(n int) int {
+    if n < 0 {
+        return 0
+    }
+    return int(new(big.Int).Sqrt(big.NewInt(int64(n))).Int64())
+}
+
+// basePrimes returns the primes up to limit with a plain sieve. It keeps one
+// bit per odd number and counts the primes before collecting them, so the
+// result is the only large allocation: for the primes up to 10^8, 6 MB of
+// bits and 46 MB of ints.
+func basePrimes(limit int) []int {
+    if limit < 2 {
+        return nil
+    }
+    // Bit i stands for the odd number 2i+1
+    odds := (limit-1)/2 + 1
+    composite := make([]uint64, (odds+63)/64)
+    marked := func(i int) bool { return composite[i/64]&(1<<(uint(i)%64)) != 0 }
+    composite[0] |= 1 // 1 is not prime
+    for i := 1; i < odds; i++ {
+        p := 2*i + 1
+        if p*p > limit {
+            break
+        }
+        if marked(i) {
+            continue
+        }
+        for j := p * p / 2; j < odds; j += p {
+            composite[j/64] |= 1 << (uint(j) % 64)
+        }
+    }
+    
+    count := 1
+    for i := 1; i < odds; i++ {
+        if !marked(i) {
+            count++
+        }
+    }
+    primes := make([]int, 1, count)
+    primes[0] = 2
+    for i := 1; i < odds; i++ {
+        if !marked(i) {
+            primes = append(primes, 2*i+1)
+        }
+    }
+    return primes
+}
+
+// FindRangeSieve finds all primes in [start, end] in ascending order with a
+// segmented Sieve of Eratosthenes
+func FindRangeSieve(start, end int) []int {
+    primes, _ := sieveRangeUntil(context.Background(), start, end, sievingPrimes(isqrt(end)), nil, time.Time{})
+    return primes
+}
+
+// sieveRangeUntil sieves [start, end] one segment at a time using base, which
+// must hold every prime up to sqrt(end), presieving with w unless it is nil.
+// Cancellation and the deadline are checked between segments.
+func sieveRangeUntil(ctx context.Context, start, end int, base []int, w *wheel, deadline time.Time) (primes []int, ok bool) {
+    primes = make([]int, 0, primeCountBound(max(start, 2), end))
+    ok = sieveSegmentsUntil(ctx, start, end, base, w, deadline, func(lo int, segment []bool) {
+        for i, c := range segment {
+            if !c {
+                primes = append(primes, lo+i)
+            }
+        }
+    })
+    if !ok {
+        return nil, false
+    }
+    return primes, true
+}
+
+// sieveCountUntil is sieveRangeUntil that only counts the primes congruent
+// to offset modulo stride, storing none of them
+func sieveCountUntil(ctx context.Context, start, end int, base []int, w *wheel, stride, offset int, deadline time.Time) (count int, ok bool) {
+    ok = sieveSegmentsUntil(ctx, start, end, base, w, deadline, func(lo int, segment []bool) {
+        step := max(stride, 1)
+        for i := firstCandidate(lo, stride, offset) - lo; i < len(segment); i += step {
+            if !segment[i] {
+                count++
+            }
+        }
+    })
+    return count, ok
+}
+
+// sieveSegmentsUntil sieves [start, end] one segment at a time and passes
+// each to visit, with segment[i] false exactly when lo+i is prime. With a
+// wheel, each segment starts from the wheel's pattern and base primes mark
+// only their multiples coprime to it. It stops early, returning false, if
+// ctx is cancelled or the deadline passes.
+func sieveSegmentsUntil(ctx context.Context, start, end int, base []int, w *wheel, deadline time.Time, visit func(lo int, segment []bool)) bool {
+    if start < 2 {
+        start = 2
+    }
+    if end < start {
+        return true
+    }
+    composite := make([]bool, min(segmentSize, end-start+1))
+    
+    for lo := start; lo <= end; lo += segmentSize {
+        if expired(ctx, deadline) {
+            return false
+        }
+        hi := min(lo+segmentSize-1, end)
+        segment := composite[:hi-lo+1]
+        if w == nil {
+            clear(segment)
+            markMultiples(segment, lo, hi, base)
+        } else {
+            w.markMultiples(segment, lo, hi, base)
+        }
+        visit(lo, segment)
+    }
+    return true
+}
+
+// markMultiples marks the multiples of base in segment, which holds [lo, hi],
+// skipping each prime itself
+func markMultiples(segment []bool, lo, hi int, base []int) {
+    for _, p := range base {
+        if p*p > hi {
+            break
+        }
+        // First multiple of p in the segment, skipping p itself
+        first := max(p*p, (lo+p-1)/p*p)
+        for m := first; m <= hi; m += p {
+            segment[m-lo] = true
+        }
+    }
+}
+
+// markMultiples fills segment, which holds [lo, hi], with the numbers that
+// share a factor with the wheel, then marks the multiples p*k of the other
+// base primes for k coprime to the wheel only
+func (w *wheel) markMultiples(segment []bool, lo, hi int, base []int) {
+    n := copy(segment, w.pattern[lo%w.size:])
+    for n < len(segment) {
+        n += copy(segment[n:], w.pattern)
+    }
+    for _, p := range w.primes {
+        if lo <= p && p <= hi {
+            segment[p-lo] = false
+        }
+    }
+    for _, p := range base {
+        if p*p > hi {
+            break
+        }
+        if w.size%p == 0 {
+            continue
+        }
+        k, j := w.next(max(p, (lo+p-1)/p))
+        for m := p * k; m <= hi; {
+            segment[m-lo] = true
+            m += p * w.gaps[j]
+            if j++; j == len(w.gaps) {
+                j = 0
+            }
+        }
+    }
+}
@@ -0,0 +1,82 @@
+// sieve_test.go
+package primefinder
+
+import (
+    "errors"
+    "reflect"
+    "testing"
+)
+
+func TestFindRangeSieve(t *testing.T) {
+    tests := []struct {
+        name       string
+        start, end int
+    }{
+        {"small", 1, 100},
+        {"below two", -10, 10},
+        {"single prime", 97, 97},
+        {"single square", 121, 121},
+        {"empty", 10, 5},
+        {"segment boundary", segmentSize - 50, segmentSize + 50},
+        {"several segments", 1, 3*segmentSize + 7},
+        {"offset start", 1000003, 1200000},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got := FindRangeSieve(tt.start, tt.end)
+            expected := FindRange(tt.start, tt.end)
+            if len(got) != len(expected) || (len(got) > 0 && !reflect.DeepEqual(got, expected)) {
+                t.Errorf("FindRangeSieve(%d, %d) returned %d primes, expected %d", tt.start, tt.end, len(got), len(expected))
+            }
+        })
+    }
+}
+
+func TestBasePrimes(t *testing.T) {
+    for _, limit := range []int{-1, 0, 1, 2, 3, 4, 9, 25, 63, 64, 65, 128, 1000, 99991} {
+        if got, expected := basePrimes(limit), FindRange(2, limit); !reflect.DeepEqual(got, expected) && len(got)+len(expected) > 0 {
+            t.Errorf("basePrimes(%d): %d primes, expected %d", limit, len(got), len(expected))
+        }
+    }
+}
+
+func TestResolveAlgorithm(t *testing.T) {
+    tests := []struct {
+        name     string
+        end      int
+        expected string
+        err      error
+    }{
+        {"", 1000, AlgorithmTrial, nil},
+        {AlgorithmAuto, sieveThreshold, AlgorithmSieve, nil},
+        {AlgorithmAuto, maxSieveEnd + 1, AlgorithmTrial, nil},
+        {AlgorithmTrial, sieveThreshold, AlgorithmTrial, nil},
+        {AlgorithmSieve, 1000, AlgorithmSieve, nil},
+        {AlgorithmSieve, maxSieveEnd + 1, "", ErrInvalidArgument},
+        {AlgorithmMillerRabin, maxSieveEnd + 1, AlgorithmMillerRabin, nil},
+        {"wheel", 1000, "", ErrInvalidArgument},
+    }
+    for _, tt := range tests {
+        got, err := ResolveAlgorithm(tt.name, tt.end)
+        if got != tt.expected || !errors.Is(err, tt.err) {
+            t.Errorf("ResolveAlgorithm(%q, %d) = %q, %v; expected %q, %v", tt.name, tt.end, got, err, tt.expected, tt.err)
+        }
+    }
+}
+
+func TestConcurrentSieve(t *testing.T) {
+    expected := FindRange(1, 200000)
+    result := FindRangeConcurrentConfig(1, 200000, 4, Config{Algorithm: AlgorithmSieve})
+    if result.Err != nil || result.Algorithm != AlgorithmSieve {
+        t.Fatalf("Unexpected result: algorithm %q, err %v", result.Algorithm, result.Err)
+    }
+    if !reflect.DeepEqual(result.Primes, expected) {
+        t.Errorf("Sieve found %d primes, trial division %d", len(result.Primes), len(expected))
+    }
+}
+
+func BenchmarkFindPrimesSieve(b *testing.B) {
+    for i := 0; i < b.N; i++ {
+        FindRangeSieve(1, 100000)
+    }
+}
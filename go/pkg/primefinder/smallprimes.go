@@ -0,0 +1,25 @@
+// smallprimes.go
+package primefinder
+
+// smallPrimeLimit bounds the numbers whose primality IsPrime looks up
+// instead of dividing; most calls in typical ranges fall below it
+const smallPrimeLimit = 1 << 20
+
+// smallPrimeBits has bit n set for each prime n below smallPrimeLimit, in
+// 128 KiB
+var smallPrimeBits = buildSmallPrimeBits()
+
+// buildSmallPrimeBits sieves the primes below smallPrimeLimit into a bitset
+func buildSmallPrimeBits() []uint64 {
+    set := make([]uint64, smallPrimeLimit/64)
+    for _, p := range basePrimes(smallPrimeLimit - 1) {
+        set[p>>6] |= 1 << (p & 63)
+    }
+    return set
+}
+
+// isSmallPrime reports whether n, which must be in [0, smallPrimeLimit), is
+// prime
+func isSmallPrime(n int) bool {
+    return smallPrimeBits[n>>6]&(1<<(n&63)) != 0
+}
@@ -0,0 +1,33 @@
+// smallprimes_test.go
+package primefinder
+
+import "testing"
+
+func TestSmallPrimeBits(t *testing.T) {
+    count := 0
+    for n := 0; n < smallPrimeLimit; n++ {
+        if isSmallPrime(n) != wheel6.isPrimeAfter(n, nil) {
+            t.Fatalf("isSmallPrime(%d) = %v, trial division says otherwise", n, isSmallPrime(n))
+        }
+        if isSmallPrime(n) {
+            count++
+        }
+    }
+    // pi(2^20)
+    if count != 82025 {
+        t.Errorf("found %d primes below 2^20, expected 82025", count)
+    }
+    
+    // IsPrime takes the table below the limit and divides from it up
+    for _, n := range []int{-7, 0, 1, 2, smallPrimeLimit - 3, smallPrimeLimit - 1, smallPrimeLimit, smallPrimeLimit + 7} {
+        if IsPrime(n) != wheel6.isPrimeAfter(n, nil) {
+            t.Errorf("IsPrime(%d) = %v", n, IsPrime(n))
+        }
+    }
+}
+
+func BenchmarkIsPrimeSmall(b *testing.B) {
+    for i := 0; i < b.N; i++ {
+        IsPrime(999983) // the largest prime below 10^6, from the table
+    }
+}
@@ -0,0 +1,53 @@
+// stream.go
+package primefinder
+
+import (
+    "context"
+    "fmt"
+)
+
+// FindRangeStream finds primes in [start, end] using concurrent workers and
+// sends them on the returned channel in ascending order as chunks complete,
+// so the full list is never held in memory. The primes channel is closed
+// when the search ends; the error channel then yields at most one error
+// and is closed. A slow consumer holds the workers back rather than letting
+// results pile up. With Config.Unordered the primes arrive in chunk
+// completion order instead.
+func FindRangeStream(ctx context.Context, start, end, workers int) (<-chan int, <-chan error) {
+    return FindRangeStreamConfig(ctx, start, end, workers, Config{})
+}
+
+// FindRangeStreamConfig is FindRangeStream with optional tuning
+func FindRangeStreamConfig(ctx context.Context, start, end, workers int, cfg Config) (<-chan int, <-chan error) {
+    primes := make(chan int, 1024)
+    errs := make(chan error, 1)
+    
+    go func() {
+        defer close(errs)
+        defer close(primes)
+        
+        if err := ValidateRange(start, end, workers); err != nil {
+            errs <- err
+            return
+        }
+        result := search(ctx, start, end, workers, cfg, func(chunk []int) {
+            for _, p := range chunk {
+                select {
+                case primes <- p:
+                case <-ctx.Done():
+                    return
+                }
+            }
+        })
+        
+        switch {
+        case result.Err != nil:
+            errs <- result.Err
+        case result.Cancelled || ctx.Err() != nil:
+            errs <- fmt.Errorf("%w: %v", ErrCancelled, ctx.Err())
+        case len(result.Quarantined) > 0:
+            errs <- fmt.Errorf("%w: %d chunks quarantined after repeated timeouts", ErrWorkerLost, len(result.Quarantined))
+        }
+    }()
+    return primes, errs
+}
@@ -0,0 +1,53 @@
+// stream_test.go
+package primefinder
+
+import (
+    "context"
+    "errors"
+    "reflect"
+    "testing"
+)
+
+func TestFindRangeStream(t *testing.T) {
+    primes, errs := FindRangeStream(context.Background(), 1, 200000, 4)
+    var got []int
+    for p := range primes {
+        got = append(got, p)
+    }
+    if err := <-errs; err != nil {
+        t.Fatal(err)
+    }
+    if expected := FindRange(1, 200000); !reflect.DeepEqual(got, expected) {
+        t.Errorf("Stream gave %d primes, expected %d in order", len(got), len(expected))
+    }
+}
+
+func TestFindRangeStreamCancel(t *testing.T) {
+    ctx, cancel := context.WithCancel(context.Background())
+    defer cancel()
+    primes, errs := FindRangeStream(ctx, 1, 10000000, 4)
+    
+    // Stop consuming after a few primes; the search must still finish
+    count := 0
+    for range primes {
+        if count++; count == 10 {
+            cancel()
+            break
+        }
+    }
+    for range primes {
+    }
+    if err := <-errs; !errors.Is(err, ErrCancelled) {
+        t.Errorf("Cancelled stream returned %v, expected ErrCancelled", err)
+    }
+}
+
+func TestFindRangeStreamInvalid(t *testing.T) {
+    primes, errs := FindRangeStream(context.Background(), 10, 1, 4)
+    if _, ok := <-primes; ok {
+        t.Error("Invalid range produced primes")
+    }
+    if err := <-errs; !errors.Is(err, ErrInvalidRange) {
+        t.Errorf("Invalid range returned %v", err)
+    }
+}
@@ -0,0 +1,168 @@
+// transform.go
+package primefinder
+
+import (
+    "fmt"
+    "strconv"
+    "strings"
+)
+
+// Transform is a stage between the search and the output. It receives
+// ascending batches of primes and returns the primes to pass downstream.
+// A concurrent search with Config.Pipeline hands it each chunk's primes as
+// the chunk is merged, so stages that look at neighbouring primes keep
+// state across batches to see across chunk boundaries. Flush returns
+// whatever a stage still owes once the last batch has been applied, which
+// is nothing for the built-in stages.
+type Transform interface {
+    Apply(batch []int) []int
+    Flush() []int
+}
+
+// Pipeline chains transforms so the output of each feeds the next
+type Pipeline []Transform
+
+// Apply runs one batch through every stage
+func (p Pipeline) Apply(batch []int) []int {
+    for _, t := range p {
+        batch = t.Apply(batch)
+    }
+    return batch
+}
+
+// Flush drains every stage in order, pushing primes held back by earlier
+// stages through the stages that follow
+func (p Pipeline) Flush() []int {
+    var out []int
+    for _, t := range p {
+        out = append(t.Apply(out), t.Flush()...)
+    }
+    return out
+}
+
+// Run applies the pipeline to a complete result and flushes it
+func (p Pipeline) Run(primes []int) []int {
+    out := p.Apply(primes)
+    return append(out, p.Flush()...)
+}
+
+// Tap returns a stage that runs each batch through t for what t finds in
+// it, handing t's output to out, and passes the batch on unchanged. It lets
+// a filtering stage such as a PairFinder report on the primes without
+// dropping any.
+func Tap(t Transform, out func([]int)) Transform {
+    return &tapTransform{t: t, out: out}
+}
+
+type tapTransform struct {
+    t   Transform
+    out func([]int)
+}
+
+func (t *tapTransform) Apply(batch []int) []int {
+    t.out(t.t.Apply(batch))
+    return batch
+}
+
+func (t *tapTransform) Flush() []int {
+    t.out(t.t.Flush())
+    return nil
+}
+
+// dedupeTransform drops primes that were already emitted
+type dedupeTransform struct {
+    seen map[int]bool
+}
+
+func (t *dedupeTransform) Apply(batch []int) []int {
+    var out []int
+    for _, p := range batch {
+        if !t.seen[p] {
+            t.seen[p] = true
+            out = append(out, p)
+        }
+    }
+    return out
+}
+
+func (t *dedupeTransform) Flush() []int { return nil }
+
+// sampleTransform keeps every nth prime
+type sampleTransform struct {
+    every int
+    count int
+}
+
+func (t *sampleTransform) Apply(batch []int) []int {
+    var out []int
+    for _, p := range batch {
+        if t.count%t.every == 0 {
+            out = append(out, p)
+        }
+        t.count++
+    }
+    return out
+}
+
+func (t *sampleTransform) Flush() []int { return nil }
+
+// residueTransform classifies primes by residue and keeps those that are
+// congruent to remainder modulo modulus
+type residueTransform struct {
+    modulus   int
+    remainder int
+}
+
+func (t *residueTransform) Apply(batch []int) []int {
+    var out []int
+    for _, p := range batch {
+        if p%t.modulus == t.remainder {
+            out = append(out, p)
+        }
+    }
+    return out
+}
+
+func (t *residueTransform) Flush() []int { return nil }
+
+// ParseTransforms builds a pipeline from a comma-separated spec such as
+// "dedupe,residue:4:3,sample:10". Supported stages:
+//   dedupe          drop repeated primes
+//   sample:N        keep every Nth prime
+//   residue:M:R     keep primes congruent to R mod M
+//   pairs:G         keep p when p+G is prime too, as -pairs does (see PairFinder)
+func ParseTransforms(spec string) (Pipeline, error) {
+    var pipeline Pipeline
+    if spec == "" {
+        return pipeline, nil
+    }
+    
+    for _, stage := range strings.Split(spec, ",") {
+        parts := strings.Split(strings.TrimSpace(stage), ":")
+        args := make([]int, len(parts)-1)
+        for i, arg := range parts[1:] {
+            n, err := strconv.Atoi(arg)
+            if err != nil {
+                return nil, fmt.Errorf("transform %q: invalid argument %q", stage, arg)
+            }
+            args[i] = n
+        }
+        
+        switch {
+        case parts[0] == "dedupe" && len(args) == 0:
+            pipeline = append(pipeline, &dedupeTransform{seen: make(map[int]bool)})
+        case parts[0] == "sample" && len(args) == 1 && args[0] > 0:
+            pipeline = append(pipeline, &sampleTransform{every: args[0]})
+        case parts[0] == "residue" && len(args) == 2:
+            if args[0] <= 0 || args[1] < 0 || args[1] >= args[0] {
+                return nil, fmt.Errorf("transform %q: need 0 <= R < M", stage)
+            }
+            pipeline = append(pipeline, &residueTransform{modulus: args[0], remainder: args[1]})
+        case parts[0] == "pairs" && len(args) == 1 && args[0] > 0:
+            pipeline = append(pipeline, NewPairFinder(args[0]))
+        default:
+            return nil, fmt.Errorf("unknown or malformed transform %q", stage)
+        }
+    }
+    return pipeline, nil
+}
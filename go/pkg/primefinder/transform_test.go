@@ -0,0 +1,71 @@
+// transform_test.go
+package primefinder
+
+import (
+    "context"
+    "errors"
+    "reflect"
+    "testing"
+)
+
+func TestParseTransforms(t *testing.T) {
+    for _, spec := range []string{"bogus", "sample", "sample:0", "residue:4", "residue:0:0", "residue:-4:1", "residue:4:4", "residue:4:-1", "pairs:x"} {
+        if _, err := ParseTransforms(spec); err == nil {
+            t.Errorf("ParseTransforms(%q) succeeded, expected error", spec)
+        }
+    }
+    
+    pipeline, err := ParseTransforms("dedupe,residue:4:3,sample:2")
+    if err != nil {
+        t.Fatalf("ParseTransforms failed: %v", err)
+    }
+    if len(pipeline) != 3 {
+        t.Errorf("Expected 3 stages, got %d", len(pipeline))
+    }
+}
+
+func TestPipelineAcrossBatches(t *testing.T) {
+    pipeline, _ := ParseTransforms("dedupe,pairs:2")
+    
+    // Twin pair (17, 19) straddles the batch boundary and 17 is repeated
+    var out []int
+    out = append(out, pipeline.Apply([]int{2, 3, 5, 7, 11, 13, 17})...)
+    out = append(out, pipeline.Apply([]int{17, 19, 23, 29, 31})...)
+    out = append(out, pipeline.Flush()...)
+    
+    expected := []int{3, 5, 11, 17, 29}
+    if !reflect.DeepEqual(out, expected) {
+        t.Errorf("Pipeline produced %v, expected %v", out, expected)
+    }
+    
+    // Pairs need not be consecutive primes: (7, 13) is a sexy pair
+    pipeline, _ = ParseTransforms("pairs:6")
+    if out, expected := pipeline.Run(FindRange(1, 30)), []int{5, 7, 11, 13, 17, 23}; !reflect.DeepEqual(out, expected) {
+        t.Errorf("pairs:6 produced %v, expected %v", out, expected)
+    }
+}
+
+func TestSearchPipeline(t *testing.T) {
+    // Small chunks put pairs and gaps across many chunk boundaries
+    primes := FindRange(1, 200000)
+    expected, _ := ParseTransforms("pairs:2,sample:3")
+    gaps, _ := NewGapStats(2)
+    gaps.Add(primes)
+    
+    stats, _ := NewGapStats(2)
+    pipeline, _ := ParseTransforms("pairs:2,sample:3")
+    result := FindRangeConcurrentContext(context.Background(), 1, 200000, 4, Config{MaxChunk: 997, Pipeline: append(Pipeline{stats}, pipeline...)})
+    if result.Err != nil {
+        t.Fatal(result.Err)
+    }
+    if want := expected.Run(primes); !reflect.DeepEqual(result.Primes, want) || result.Count != len(primes) {
+        t.Errorf("got %d primes of %d found, expected %d of %d", len(result.Primes), result.Count, len(want), len(primes))
+    }
+    if !reflect.DeepEqual(stats.Report(), gaps.Report()) {
+        t.Errorf("gaps over merged chunks %+v, expected %+v", stats.Report(), gaps.Report())
+    }
+    
+    if result := FindRangeConcurrentContext(context.Background(), 1, 1000, 2, Config{Descending: true, Pipeline: pipeline}); !errors.Is(result.Err, ErrInvalidArgument) {
+        t.Errorf("descending search with a pipeline: got %v, expected ErrInvalidArgument", result.Err)
+    }
+}
@@ -0,0 +1,58 @@
+// verdict.go
+package primefinder
+
+import (
+    "math"
+    "math/big"
+)
+
+// Primality methods named in a Verdict
+const (
+    MethodTrial       = "trial"        // trial division, exact
+    MethodSieve       = "sieve"        // Sieve of Eratosthenes, exact
+    MethodMillerRabin = "miller-rabin" // exact with the 64-bit witness set, probabilistic with random bases
+    MethodBPSW        = "baillie-psw"  // Miller-Rabin plus Baillie-PSW, exact below 2^64
+)
+
+// Verdict records how a number was judged prime and how far the answer can
+// be trusted. Composites are always proven, so ErrorProbability bounds the
+// chance that a reported prime is in fact composite; it is 0 for exact
+// methods.
+type Verdict struct {
+    Method           string  `json:"method"`
+    Exact            bool    `json:"exact"`
+    Rounds           int     `json:"rounds,omitempty"`
+    ErrorProbability float64 `json:"error_probability"`
+}
+
+// roundsVerdict is the verdict of a test that a composite passes with
+// probability at most 4^-rounds
+func roundsVerdict(method string, rounds int) Verdict {
+    return Verdict{Method: method, Rounds: rounds, ErrorProbability: math.Pow(4, -float64(rounds))}
+}
+
+// AlgorithmVerdict returns the verdict attached to every prime a search with
+// the given resolved algorithm and Config.MRRounds reports
+func AlgorithmVerdict(algorithm string, mrRounds int) Verdict {
+    switch algorithm {
+    case AlgorithmSieve:
+        return Verdict{Method: MethodSieve, Exact: true}
+    case AlgorithmMillerRabin:
+        if mrRounds > 0 {
+            return roundsVerdict(MethodMillerRabin, mrRounds)
+        }
+        return Verdict{Method: MethodMillerRabin, Exact: true}
+    }
+    return Verdict{Method: MethodTrial, Exact: true}
+}
+
+// ProbablePrimeVerdict returns the verdict of big.Int.ProbablyPrime as used
+// by IsProbablePrime and FindRangeBig on n: exact below 2^64, and above it
+// bounded by the Miller-Rabin rounds alone, since Baillie-PSW has no proven
+// error bound (though no counterexample is known)
+func ProbablePrimeVerdict(n *big.Int) Verdict {
+    if n.Sign() >= 0 && n.IsUint64() {
+        return Verdict{Method: MethodBPSW, Exact: true}
+    }
+    return roundsVerdict(MethodBPSW, bigRounds)
+}
@@ -0,0 +1,32 @@
+// verdict_test.go
+package primefinder
+
+import (
+    "math/big"
+    "testing"
+)
+
+func TestVerdicts(t *testing.T) {
+    huge, _ := new(big.Int).SetString("170141183460469231731687303715884105727", 10)
+    tests := []struct {
+        name     string
+        verdict  Verdict
+        method   string
+        exact    bool
+        errorMax float64
+    }{
+        {"trial", AlgorithmVerdict(AlgorithmTrial, 0), MethodTrial, true, 0},
+        {"sieve", AlgorithmVerdict(AlgorithmSieve, 5), MethodSieve, true, 0},
+        {"witnesses", AlgorithmVerdict(AlgorithmMillerRabin, 0), MethodMillerRabin, true, 0},
+        {"1 round", AlgorithmVerdict(AlgorithmMillerRabin, 1), MethodMillerRabin, false, 0.25},
+        {"10 rounds", AlgorithmVerdict(AlgorithmMillerRabin, 10), MethodMillerRabin, false, 1e-6},
+        {"64-bit", ProbablePrimeVerdict(new(big.Int).SetUint64(18446744073709551557)), MethodBPSW, true, 0},
+        {"128-bit", ProbablePrimeVerdict(huge), MethodBPSW, false, 1e-12},
+    }
+    for _, tt := range tests {
+        v := tt.verdict
+        if v.Method != tt.method || v.Exact != tt.exact || v.ErrorProbability > tt.errorMax || (v.ErrorProbability == 0) != tt.exact {
+            t.Errorf("%s: got %+v, expected method %s, exact %v, error at most %g", tt.name, v, tt.method, tt.exact, tt.errorMax)
+        }
+    }
+}
@@ -0,0 +1,142 @@
+// wheel.go
+package primefinder
+
+import "fmt"
+
+// wheel is a factorization wheel: past its primes, only numbers coprime to
+// their product can be prime, and those repeat with period size
+type wheel struct {
+    size     int    // product of primes
+    primes   []int  // primes whose multiples the wheel skips
+    residues []int  // numbers in [1, size) coprime to size, ascending
+    gaps     []int  // gaps[j] leads from residues[j] to the next coprime number
+    pos      []int  // pos[r] is the index of the least residue >= r, or len(residues)
+    pattern  []bool // pattern[r] reports whether r shares a factor with size
+}
+
+// newWheel builds the wheel of the given primes
+func newWheel(primes ...int) *wheel {
+    w := &wheel{size: 1, primes: primes}
+    for _, p := range primes {
+        w.size *= p
+    }
+    w.pattern = make([]bool, w.size)
+    w.pattern[0] = true
+    for r := 1; r < w.size; r++ {
+        for _, p := range primes {
+            if r%p == 0 {
+                w.pattern[r] = true
+                break
+            }
+        }
+        if !w.pattern[r] {
+            w.residues = append(w.residues, r)
+        }
+    }
+    for j, r := range w.residues {
+        next := w.size + w.residues[0]
+        if j+1 < len(w.residues) {
+            next = w.residues[j+1]
+        }
+        w.gaps = append(w.gaps, next-r)
+    }
+    w.pos = make([]int, w.size+1)
+    j := len(w.residues)
+    w.pos[w.size] = j
+    for r := w.size - 1; r >= 0; r-- {
+        if !w.pattern[r] {
+            j--
+        }
+        w.pos[r] = j
+    }
+    return w
+}
+
+// wheel6 steps through 6k-1 and 6k+1, the candidates IsPrime divides by
+var wheel6 = newWheel(2, 3)
+
+// wheels holds the wheels selectable with Config.Wheel, by size
+var wheels = map[int]*wheel{
+    6:   wheel6,
+    30:  newWheel(2, 3, 5),
+    210: newWheel(2, 3, 5, 7),
+}
+
+// ValidateWheel checks a wheel size for Config.Wheel: 0 for the default,
+// or 6, 30 or 210
+func ValidateWheel(size int) error {
+    if _, ok := wheels[size]; !ok && size != 0 {
+        return fmt.Errorf("%w: wheel must be 6, 30 or 210, got %d", ErrInvalidArgument, size)
+    }
+    return nil
+}
+
+// next returns the least k >= x coprime to the wheel, for x >= 0, and the
+// index of its gap
+func (w *wheel) next(x int) (k, j int) {
+    base, r := x-x%w.size, x%w.size
+    j = w.pos[r]
+    if j == len(w.residues) {
+        base, j = base+w.size, 0
+    }
+    return base + w.residues[j], j
+}
+
+// isPrime looks n up in the small-prime bitset below smallPrimeLimit and
+// otherwise tests it by trial division, first by the cached primes and past
+// them by the numbers that share no factor with the wheel
+func (w *wheel) isPrime(n int) bool {
+    if uint(n) < smallPrimeLimit {
+        return isSmallPrime(n)
+    }
+    return w.isPrimeAfter(n, loadCache().primes)
+}
+
+// isPrimeAfter tests n by trial division by primes, which must hold every
+// prime up to its last, then by the divisors the wheel does not skip
+func (w *wheel) isPrimeAfter(n int, primes []int) bool {
+    if n < 2 {
+        return false
+    }
+    for _, p := range primes {
+        if p*p > n {
+            return true
+        }
+        if n%p == 0 {
+            return n == p
+        }
+    }
+    for _, p := range w.primes {
+        if n%p == 0 {
+            return n == p
+        }
+    }
+    // The first divisor past 1 is the least prime not on the wheel
+    d, j := w.residues[1], 1
+    if len(primes) > 0 {
+        d, j = w.next(primes[len(primes)-1] + 1)
+    }
+    for d*d <= n {
+        if n%d == 0 {
+            return false
+        }
+        d += w.gaps[j]
+        if j++; j == len(w.gaps) {
+            j = 0
+        }
+    }
+    return true
+}
+
+// IsPrimeWheel is IsPrime with a wheel of the given size, 6, 30 or 210:
+// past the cached primes, larger wheels try fewer divisors, 8 in every 30
+// or 48 in every 210 instead of 2 in every 6. Only numbers above the square
+// of the cache bound (10^12 by default) get past the cached primes, so
+// below that the size makes no difference. It panics on any other size.
+func IsPrimeWheel(n, size int) bool {
+    w, ok := wheels[size]
+    if !ok {
+        panic(fmt.Sprintf("primefinder: no wheel of size %d", size))
+    }
+    return w.isPrime(n)
+}
@@ -0,0 +1,143 @@
+// wheel_test.go
+package primefinder
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "reflect"
+    "testing"
+    "time"
+)
+
+func TestIsPrimeWheel(t *testing.T) {
+    // Dividing by no cached primes, or only a few, exercises the wheel
+    tables := [][]int{nil, {2}, {2, 3, 5, 7, 11, 13}, loadCache().primes}
+    prime := make([]bool, 100000)
+    for _, p := range basePrimes(len(prime) - 1) {
+        prime[p] = true
+    }
+    for _, size := range []int{6, 30, 210} {
+        for _, table := range tables {
+            for n := -5; n < len(prime); n++ {
+                if got, expected := wheels[size].isPrimeAfter(n, table), n >= 0 && prime[n]; got != expected {
+                    t.Fatalf("wheel %d after %d cached primes: isPrime(%d) = %v, expected %v", size, len(table), n, got, expected)
+                }
+            }
+        }
+        // Both are past the square of the cache bound, so the wheel decides
+        if !IsPrimeWheel(1_000_000_000_039, size) || IsPrimeWheel(1_000_003*1_000_033, size) {
+            t.Errorf("wheel %d: wrong answer past the cache", size)
+        }
+    }
+}
+
+func TestWheelNext(t *testing.T) {
+    w := wheels[30]
+    tests := []struct{ x, k int }{
+        {0, 1}, {1, 1}, {2, 7}, {7, 7}, {8, 11}, {29, 29}, {30, 31}, {32, 37}, {60, 61},
+    }
+    for _, tt := range tests {
+        k, j := w.next(tt.x)
+        if k != tt.k || w.residues[j] != k%w.size {
+            t.Errorf("next(%d) = %d, %d; expected %d", tt.x, k, j, tt.k)
+        }
+    }
+}
+
+func TestWheelSearch(t *testing.T) {
+    tests := []struct {
+        start, end int
+    }{
+        {1, 100},
+        {2, 7},
+        {200, 209},
+        {segmentSize - 50, segmentSize + 50},
+        {1, 3*segmentSize + 7},
+        {1000003, 1200000},
+    }
+    for _, algorithm := range []string{AlgorithmTrial, AlgorithmSieve} {
+        for _, size := range []int{6, 30, 210} {
+            for _, tt := range tests {
+                cfg := Config{Algorithm: algorithm, Wheel: size}
+                got, err := FindRangeConfig(tt.start, tt.end, cfg)
+                expected := FindRange(tt.start, tt.end)
+                if err != nil || !reflect.DeepEqual(got, expected) {
+                    t.Errorf("%s wheel %d [%d, %d]: %d primes, %v; expected %d", algorithm, size, tt.start, tt.end, len(got), err, len(expected))
+                }
+                count, err := CountRangeConfig(tt.start, tt.end, cfg)
+                if err != nil || count != len(expected) {
+                    t.Errorf("%s wheel %d [%d, %d]: counted %d, %v; expected %d", algorithm, size, tt.start, tt.end, count, err, len(expected))
+                }
+            }
+        }
+    }
+    
+    result := FindRangeConcurrentConfig(1, 200000, 4, Config{Algorithm: AlgorithmSieve, Wheel: 210})
+    if !reflect.DeepEqual(result.Primes, FindRange(1, 200000)) {
+        t.Errorf("concurrent wheel sieve found %d primes", len(result.Primes))
+    }
+    if _, err := FindRangeConfig(1, 100, Config{Wheel: 12}); !errors.Is(err, ErrInvalidArgument) {
+        t.Errorf("wheel 12: got %v, expected ErrInvalidArgument", err)
+    }
+}
+
+func BenchmarkIsPrimeWheel(b *testing.B) {
+    candidates := candidatesForBench(1_000_000_000_000, 16)
+    b.Run("cached", func(b *testing.B) {
+        for i := 0; i < b.N; i++ {
+            for _, c := range candidates {
+                IsPrime(c)
+            }
+        }
+    })
+    // Without cached primes, larger wheels try fewer divisors
+    for _, size := range []int{6, 30, 210} {
+        w := wheels[size]
+        b.Run(fmt.Sprintf("wheel-%d", size), func(b *testing.B) {
+            for i := 0; i < b.N; i++ {
+                for _, c := range candidates {
+                    w.isPrimeAfter(c, nil)
+                }
+            }
+        })
+    }
+}
+
+// BenchmarkIsPrimeWheelPastCache times IsPrimeWheel on primes near 10^14,
+// which the cached primes cannot decide: trial division goes on through the
+// wheel's divisors from the cache bound to 10^7. Below the square of the
+// cache bound the wheel is never reached and every size times the same.
+func BenchmarkIsPrimeWheelPastCache(b *testing.B) {
+    primes := []int{100_000_000_000_031, 100_000_000_000_067, 100_000_000_000_097, 100_000_000_000_099}
+    for _, size := range []int{6, 30, 210} {
+        b.Run(fmt.Sprintf("wheel-%d", size), func(b *testing.B) {
+            for i := 0; i < b.N; i++ {
+                for _, p := range primes {
+                    IsPrimeWheel(p, size)
+                }
+            }
+        })
+    }
+}
+
+func BenchmarkSieveWheel(b *testing.B) {
+    const start, end = 1_000_000_000, 1_010_000_000
+    base := basePrimes(isqrt(end))
+    for _, size := range []int{0, 30, 210} {
+        b.Run(fmt.Sprintf("wheel-%d", size), func(b *testing.B) {
+            for i := 0; i < b.N; i++ {
+                sieveCountUntil(context.Background(), start, end, base, wheels[size], 1, 0, time.Time{})
+            }
+        })
+    }
+}
+
+// candidatesForBench returns count odd numbers starting just above magnitude
+func candidatesForBench(magnitude, count int) []int {
+    candidates := make([]int, count)
+    for i := range candidates {
+        candidates[i] = magnitude + 1 + 2*i
+    }
+    return candidates
+}